@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// ActionsPermissionsConfig is what GitHub Actions can do in a repository.
+type ActionsPermissionsConfig struct {
+	// Disabled turns GitHub Actions off. The other settings are ignored.
+	Disabled bool
+	// AllowedActions is `all`, `local_only` for the repository's own actions, or `selected` for the
+	// actions allowed by GithubOwnedAllowed, VerifiedAllowed and PatternsAllowed.
+	AllowedActions string
+	// GithubOwnedAllowed allows actions created by GitHub, such as `actions/checkout`.
+	GithubOwnedAllowed bool
+	// VerifiedAllowed allows actions from verified creators in the marketplace.
+	VerifiedAllowed bool
+	// PatternsAllowed are other actions and reusable workflows that are allowed, such as
+	// `cachix/install-nix-action@*` or `holochain/*`.
+	PatternsAllowed []string
+	// RequireShaPinning only lets workflows use actions that are referenced by a full commit SHA.
+	RequireShaPinning bool
+	// DefaultWorkflowPermissions are the permissions of GITHUB_TOKEN in workflows that don't set
+	// their own, `read` or `write`.
+	DefaultWorkflowPermissions string
+	// CanApprovePullRequestReviews lets GITHUB_TOKEN create and approve pull requests.
+	CanApprovePullRequestReviews bool
+}
+
+// standardActionsPermissions are the Actions permissions of every managed repository, unless they
+// are replaced with SetActionsPermissions. Every action is allowed until there is an allowlist built
+// from the actions that each repository's workflows use.
+var standardActionsPermissions = ActionsPermissionsConfig{
+	AllowedActions:             "all",
+	DefaultWorkflowPermissions: "read",
+}
+
+func (permissions ActionsPermissionsConfig) validate() error {
+	if permissions.Disabled {
+		return nil
+	}
+	if !slices.Contains([]string{"all", "local_only", "selected"}, permissions.AllowedActions) {
+		return fmt.Errorf("allowed actions must be all, local_only or selected, not %q", permissions.AllowedActions)
+	}
+	if permissions.AllowedActions != "selected" && (permissions.GithubOwnedAllowed || permissions.VerifiedAllowed || len(permissions.PatternsAllowed) > 0) {
+		return fmt.Errorf("the actions that are allowed can only be chosen when allowed actions is selected")
+	}
+	if !slices.Contains([]string{"read", "write"}, permissions.DefaultWorkflowPermissions) {
+		return fmt.Errorf("default workflow permissions must be read or write, not %q", permissions.DefaultWorkflowPermissions)
+	}
+
+	return nil
+}
+
+// SetActionsPermissions replaces the standard Actions permissions of a repository, for example to
+// allow another action. Start from standardActionsPermissions so that only the differences stand out.
+func SetActionsPermissions(ctx *pulumi.Context, name string, permissions ActionsPermissionsConfig) {
+	state := stateFor(ctx)
+	if _, ok := state.actionsPermissions[name]; ok {
+		invalid(ctx, name, "the Actions permissions are set more than once")
+		return
+	}
+	state.actionsPermissions[name] = permissions
+}
+
+// actionsPermissionsFor returns the Actions permissions of the named repository.
+func (state *programState) actionsPermissionsFor(name string) ActionsPermissionsConfig {
+	if permissions, ok := state.actionsPermissions[name]; ok {
+		return permissions
+	}
+
+	return standardActionsPermissions
+}
+
+// SyncActionsPermissions applies the Actions permissions of every repository declared with
+// NewHolochainRepository. The organization's existing policy is imported and allows every action,
+// unless `restrictOrganizationActions` is set, in which case it only allows the actions that a managed
+// repository allows, since a repository can't allow an action that the organization doesn't. That
+// also restricts the repositories that aren't managed. It must be called after all repositories are
+// declared.
+func SyncActionsPermissions(ctx *pulumi.Context) error {
+	state := stateFor(ctx)
+	organization := ActionsPermissionsConfig{AllowedActions: "selected"}
+	for _, name := range state.managedRepositories {
+		component, ok := state.repositoryComponents[name]
+		if !ok || isRetired(name) {
+			continue
+		}
+		permissions := state.actionsPermissionsFor(name)
+		if err := permissions.validate(); err != nil {
+			invalid(ctx, name, "%v", err)
+			continue
+		}
+
+		if err := addActionsPermissions(ctx, name, component.Repository, permissions); err != nil {
+			return err
+		}
+		if permissions.Disabled {
+			continue
+		}
+		if permissions.AllowedActions == "all" {
+			organization.AllowedActions = "all"
+		}
+		organization.GithubOwnedAllowed = organization.GithubOwnedAllowed || permissions.GithubOwnedAllowed
+		organization.VerifiedAllowed = organization.VerifiedAllowed || permissions.VerifiedAllowed
+		for _, pattern := range permissions.PatternsAllowed {
+			if !slices.Contains(organization.PatternsAllowed, pattern) {
+				organization.PatternsAllowed = append(organization.PatternsAllowed, pattern)
+			}
+		}
+	}
+
+	if !config.GetBool(ctx, "holochain:restrictOrganizationActions") {
+		organization = ActionsPermissionsConfig{AllowedActions: "all"}
+	}
+	args := &github.ActionsOrganizationPermissionsArgs{
+		EnabledRepositories: pulumi.String("all"),
+		AllowedActions:      pulumi.String(organization.AllowedActions),
+	}
+	if organization.AllowedActions == "selected" {
+		slices.Sort(organization.PatternsAllowed)
+		args.AllowedActionsConfig = &github.ActionsOrganizationPermissionsAllowedActionsConfigArgs{
+			GithubOwnedAllowed: pulumi.Bool(organization.GithubOwnedAllowed),
+			VerifiedAllowed:    pulumi.Bool(organization.VerifiedAllowed),
+			PatternsAlloweds:   pulumi.ToStringArray(organization.PatternsAllowed),
+		}
+	}
+	_, err := github.NewActionsOrganizationPermissions(ctx, "organization-actions-permissions", args, pulumi.Import(pulumi.ID(githubOrganization)))
+
+	return err
+}
+
+func addActionsPermissions(ctx *pulumi.Context, name string, repository *github.Repository, permissions ActionsPermissionsConfig) error {
+	args := &github.ActionsRepositoryPermissionsArgs{
+		Repository: repository.Name,
+		Enabled:    pulumi.Bool(!permissions.Disabled),
+	}
+	if !permissions.Disabled {
+		args.AllowedActions = pulumi.String(permissions.AllowedActions)
+	}
+	if permissions.AllowedActions == "selected" && !permissions.Disabled {
+		args.AllowedActionsConfig = &github.ActionsRepositoryPermissionsAllowedActionsConfigArgs{
+			GithubOwnedAllowed: pulumi.Bool(permissions.GithubOwnedAllowed),
+			VerifiedAllowed:    pulumi.Bool(permissions.VerifiedAllowed),
+			PatternsAlloweds:   pulumi.ToStringArray(permissions.PatternsAllowed),
+		}
+	}
+	repositoryPermissions, err := github.NewActionsRepositoryPermissions(ctx, fmt.Sprintf("%s-actions-permissions", name), args, InRepository(ctx, name))
+	if err != nil {
+		return err
+	}
+	if permissions.Disabled {
+		return nil
+	}
+
+	if _, err := github.NewWorkflowRepositoryPermissions(ctx, fmt.Sprintf("%s-workflow-permissions", name), &github.WorkflowRepositoryPermissionsArgs{
+		Repository:                   repository.Name,
+		DefaultWorkflowPermissions:   pulumi.String(permissions.DefaultWorkflowPermissions),
+		CanApprovePullRequestReviews: pulumi.Bool(permissions.CanApprovePullRequestReviews),
+	}, InRepository(ctx, name)); err != nil {
+		return err
+	}
+	updateShaPinningThroughAPI(ctx, repositoryPermissions, permissions)
+
+	return nil
+}
+
+// updateShaPinningThroughAPI sets whether a repository requires actions to be pinned to a commit
+// SHA, which the Pulumi provider does not manage. The change is made through the GitHub API once the
+// repository's other Actions permissions are applied, and skipped during previews. Failures are
+// logged rather than failing the deployment, and the next deployment will try again.
+func updateShaPinningThroughAPI(ctx *pulumi.Context, repositoryPermissions *github.ActionsRepositoryPermissions, permissions ActionsPermissionsConfig) {
+	if ctx.DryRun() {
+		return
+	}
+
+	repositoryPermissions.Repository.ApplyT(func(repository string) string {
+		api, err := newGithubAPI(ctx)
+		updated := false
+		if err == nil {
+			updated, err = api.setShaPinningRequired(repository, permissions)
+		}
+		switch {
+		case err != nil:
+			_ = ctx.Log.Warn(fmt.Sprintf("unable to set whether %s requires actions pinned to a commit SHA: %v", repository, err), nil)
+		case updated:
+			_ = ctx.Log.Info(fmt.Sprintf("set %s to require actions pinned to a commit SHA: %t", repository, permissions.RequireShaPinning), nil)
+		}
+
+		return repository
+	})
+}
+
+// githubActionsPermissions is the Actions permissions of a repository in the REST API.
+type githubActionsPermissions struct {
+	Enabled            bool   `json:"enabled"`
+	AllowedActions     string `json:"allowed_actions,omitempty"`
+	ShaPinningRequired bool   `json:"sha_pinning_required"`
+}
+
+// setShaPinningRequired updates whether a repository requires SHA pinning, returning false if it
+// was already set. The API replaces every permission at once, so the others are sent as they are
+// declared, rather than as they were read.
+func (api *githubAPI) setShaPinningRequired(repository string, permissions ActionsPermissionsConfig) (bool, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/permissions", githubOrganization, repository)
+	var existing githubActionsPermissions
+	if err := api.do(http.MethodGet, path, nil, &existing); err != nil {
+		return false, err
+	}
+	if existing.ShaPinningRequired == permissions.RequireShaPinning {
+		return false, nil
+	}
+
+	if err := api.do(http.MethodPut, path, githubActionsPermissions{
+		Enabled:            !permissions.Disabled,
+		AllowedActions:     permissions.AllowedActions,
+		ShaPinningRequired: permissions.RequireShaPinning,
+	}, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestSetShaPinningRequired(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    string
+		permissions ActionsPermissionsConfig
+		updated     bool
+		requests    []string
+	}{
+		{
+			name:        "already set",
+			existing:    `{"enabled": true, "allowed_actions": "all", "sha_pinning_required": true}`,
+			permissions: ActionsPermissionsConfig{AllowedActions: "all", RequireShaPinning: true},
+			requests:    []string{"GET"},
+		},
+		{
+			name:        "sends the declared permissions",
+			existing:    `{"enabled": false, "allowed_actions": "local_only", "sha_pinning_required": false}`,
+			permissions: ActionsPermissionsConfig{AllowedActions: "selected", RequireShaPinning: true},
+			updated:     true,
+			requests:    []string{"GET", `PUT {"enabled":true,"allowed_actions":"selected","sha_pinning_required":true}`},
+		},
+		{
+			name:        "no longer required",
+			existing:    `{"enabled": true, "allowed_actions": "all", "sha_pinning_required": true}`,
+			permissions: ActionsPermissionsConfig{AllowedActions: "all"},
+			updated:     true,
+			requests:    []string{"GET", `PUT {"enabled":true,"allowed_actions":"all","sha_pinning_required":false}`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/holochain/lair/actions/permissions" {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				if r.Method == http.MethodGet {
+					requests = append(requests, r.Method)
+					_, _ = w.Write([]byte(test.existing))
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, r.Method+" "+string(body))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+			api := newGithubAPIWithToken("token")
+			api.baseURL = server.URL
+
+			updated, err := api.setShaPinningRequired("lair", test.permissions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated != test.updated {
+				t.Errorf("setShaPinningRequired() = %t, want %t", updated, test.updated)
+			}
+			if !slices.Equal(requests, test.requests) {
+				t.Errorf("requests are %q, want %q", requests, test.requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// HolochainRepositoryType is the type token of the HolochainRepository component.
+const HolochainRepositoryType = "holochain:index:HolochainRepository"
+
+// HolochainRepository groups every resource that manages a repository, so that `pulumi stack`
+// shows them together and `pulumi up --target` can select a single repository.
+type HolochainRepository struct {
+	pulumi.ResourceState
+
+	Repository *github.Repository
+}
+
+// NewHolochainRepository declares a repository inside its own HolochainRepository component. The
+// resources that helpers create for the repository are added to the component with InRepository.
+// A deprecation banner is added to repositories that ask for one, see RepositoryLifecycleConfig.
+func NewHolochainRepository(ctx *pulumi.Context, name string, args *github.RepositoryArgs, opts ...pulumi.ResourceOption) (*github.Repository, error) {
+	component := &HolochainRepository{}
+	if err := ctx.RegisterComponentResource(HolochainRepositoryType, name, component); err != nil {
+		return nil, err
+	}
+	state := stateFor(ctx)
+	state.repositoryComponents[name] = component
+
+	repository, err := github.NewRepository(ctx, name, args, append(opts, InRepository(ctx, name))...)
+	if err != nil {
+		return nil, err
+	}
+	component.Repository = repository
+
+	if err := addDeprecationBanner(ctx, name, repository); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"name":    repository.Name,
+		"htmlUrl": repository.HtmlUrl,
+	}); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// InRepository adds a resource to the HolochainRepository component of the named repository. The
+// resources were created at the top of the stack before the components existed, so the alias lets
+// Pulumi move them into the component without replacing them. Repositories that are not declared
+// with NewHolochainRepository keep their resources at the top of the stack.
+func InRepository(ctx *pulumi.Context, name string) pulumi.ResourceOption {
+	component, ok := stateFor(ctx).repositoryComponents[name]
+	if !ok {
+		return pulumi.Composite()
+	}
+
+	return pulumi.Composite(
+		pulumi.Parent(component),
+		pulumi.Aliases([]pulumi.Alias{{NoParent: pulumi.Bool(true)}}),
+	)
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DependabotEcosystem is a `package-ecosystem` supported by Dependabot.
+type DependabotEcosystem string
+
+const (
+	DependabotGithubActions DependabotEcosystem = "github-actions"
+	DependabotCargo         DependabotEcosystem = "cargo"
+	DependabotRustToolchain DependabotEcosystem = "rust-toolchain"
+	DependabotNpm           DependabotEcosystem = "npm"
+	DependabotGomod         DependabotEcosystem = "gomod"
+	DependabotNix           DependabotEcosystem = "nix"
+	DependabotDocker        DependabotEcosystem = "docker"
+	DependabotPip           DependabotEcosystem = "pip"
+)
+
+// DependabotConfig describes the .github/dependabot.yml for a repository.
+//
+// GitHub Actions are always updated weekly. The Enable flags add the standard updates for an
+// ecosystem at the repository root, and Updates can be used for anything else, such as nested
+// packages, other ecosystems or release branches.
+type DependabotConfig struct {
+	// EnableRust adds weekly cargo and rust-toolchain updates.
+	EnableRust bool
+	// EnableNpm adds monthly npm updates.
+	EnableNpm bool
+	// EnableGo adds monthly gomod updates.
+	EnableGo bool
+	// EnableNix adds monthly nix updates.
+	EnableNix bool
+	// Updates are added after the standard updates.
+	Updates []DependabotUpdate
+	// Registries are private registries that updates can be given access to.
+	Registries []DependabotRegistry
+}
+
+// DependabotUpdate is an entry in the `updates` list of dependabot.yml.
+type DependabotUpdate struct {
+	Ecosystem DependabotEcosystem
+	// Directories containing the manifests, `/` if not set. Globs such as `/crates/*` are allowed.
+	Directories []string
+	// TargetBranch to open pull requests against, the default branch if not set.
+	TargetBranch string
+	Schedule     DependabotSchedule
+	Groups       []DependabotGroup
+	Ignore       []DependabotIgnore
+	Labels       []string
+	Reviewers    []string
+	// Registries are the names of entries in DependabotConfig.Registries that the update can use.
+	Registries []string
+}
+
+// DependabotSchedule controls when Dependabot checks for updates.
+type DependabotSchedule struct {
+	// Interval is daily, weekly, monthly, quarterly, semiannually or yearly. Weekly if not set.
+	Interval string
+	// Day of the week to run weekly updates, for example `monday`.
+	Day string
+	// Time of day to run updates, as `hh:mm`.
+	Time string
+	// Timezone that Time is in, for example `Europe/London`. UTC if not set.
+	Timezone string
+}
+
+// DependabotGroup groups the updates for several dependencies into a single pull request.
+type DependabotGroup struct {
+	Name string
+	// AppliesTo is version-updates or security-updates. Version updates if not set.
+	AppliesTo   string
+	Patterns    []string
+	UpdateTypes []string
+}
+
+// DependabotIgnore stops Dependabot from updating a dependency, or some versions of it.
+type DependabotIgnore struct {
+	DependencyName string
+	Versions       []string
+	UpdateTypes    []string
+}
+
+// DependabotRegistry is a private registry that Dependabot can authenticate with.
+type DependabotRegistry struct {
+	Name string
+	// Type of the registry, for example `cargo-registry`, `npm-registry` or `docker-registry`.
+	Type string
+	URL  string
+	// Username to authenticate with, if the registry requires one.
+	Username string
+	// PasswordSecret is the name of the Dependabot secret that holds the password.
+	PasswordSecret string
+	// TokenSecret is the name of the Dependabot secret that holds the token.
+	TokenSecret  string
+	ReplacesBase bool
+}
+
+var (
+	dependabotIntervals  = []string{"daily", "weekly", "monthly", "quarterly", "semiannually", "yearly"}
+	dependabotDays       = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+	dependabotTime       = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	dependabotDirectory  = regexp.MustCompile(`^/[A-Za-z0-9_./*@{},-]*$`)
+	dependabotSecretName = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+)
+
+// resolve returns a copy of the config where Updates is the complete list of updates to render,
+// with defaults applied, or an error if the config is invalid.
+func (config DependabotConfig) resolve() (DependabotConfig, error) {
+	updates := []DependabotUpdate{
+		{Ecosystem: DependabotGithubActions, Groups: []DependabotGroup{{Name: "updates", Patterns: []string{"*"}}}},
+	}
+	if config.EnableRust {
+		updates = append(updates,
+			DependabotUpdate{Ecosystem: DependabotCargo, Groups: []DependabotGroup{{Name: "cargo-minor-patch", Patterns: []string{"*"}, UpdateTypes: []string{"minor", "patch"}}}},
+			DependabotUpdate{Ecosystem: DependabotRustToolchain},
+		)
+	}
+	if config.EnableNpm {
+		updates = append(updates, DependabotUpdate{Ecosystem: DependabotNpm, Schedule: DependabotSchedule{Interval: "monthly"}, Groups: []DependabotGroup{{Name: "npm", Patterns: []string{"*"}}}})
+	}
+	if config.EnableGo {
+		updates = append(updates, DependabotUpdate{Ecosystem: DependabotGomod, Schedule: DependabotSchedule{Interval: "monthly"}, Groups: []DependabotGroup{{Name: "gomod", Patterns: []string{"*"}}}})
+	}
+	if config.EnableNix {
+		updates = append(updates, DependabotUpdate{Ecosystem: DependabotNix, Schedule: DependabotSchedule{Interval: "monthly"}, Groups: []DependabotGroup{{Name: "nix", Patterns: []string{"*"}}}})
+	}
+	updates = append(updates, config.Updates...)
+
+	registries := map[string]bool{}
+	for _, registry := range config.Registries {
+		if err := registry.validate(); err != nil {
+			return config, err
+		}
+		if registries[registry.Name] {
+			return config, fmt.Errorf("registry %q is defined more than once", registry.Name)
+		}
+		registries[registry.Name] = true
+	}
+
+	// Dependabot rejects a config with two updates for the same ecosystem, directory and branch.
+	seen := map[string]bool{}
+	for i, update := range updates {
+		update = update.withDefaults()
+		if err := update.validate(registries); err != nil {
+			return config, fmt.Errorf("%s update: %w", update.Ecosystem, err)
+		}
+		for _, directory := range update.Directories {
+			key := fmt.Sprintf("%s %s %s", update.Ecosystem, directory, update.TargetBranch)
+			if seen[key] {
+				return config, fmt.Errorf("%s updates for %s on branch %q are defined more than once", update.Ecosystem, directory, update.TargetBranch)
+			}
+			seen[key] = true
+		}
+		updates[i] = update
+	}
+
+	config.Updates = updates
+	return config, nil
+}
+
+func (update DependabotUpdate) withDefaults() DependabotUpdate {
+	if len(update.Directories) == 0 {
+		update.Directories = []string{"/"}
+	}
+	if update.Schedule.Interval == "" {
+		update.Schedule.Interval = "weekly"
+	}
+	groups := make([]DependabotGroup, len(update.Groups))
+	for i, group := range update.Groups {
+		if group.AppliesTo == "" {
+			group.AppliesTo = "version-updates"
+		}
+		groups[i] = group
+	}
+	update.Groups = groups
+
+	return update
+}
+
+// UsesDirectories reports whether the update must be rendered with `directories` rather than
+// `directory`, because it has more than one directory or a glob, which `directory` doesn't accept.
+func (update DependabotUpdate) UsesDirectories() bool {
+	return len(update.Directories) > 1 || slices.ContainsFunc(update.Directories, func(directory string) bool {
+		return strings.ContainsAny(directory, "*?{}[]")
+	})
+}
+
+func (update DependabotUpdate) validate(registries map[string]bool) error {
+	if update.Ecosystem == "" {
+		return fmt.Errorf("an ecosystem is required")
+	}
+	for _, directory := range update.Directories {
+		if !dependabotDirectory.MatchString(directory) {
+			return fmt.Errorf("directory %q must be an absolute path from the repository root", directory)
+		}
+	}
+	if strings.ContainsAny(update.TargetBranch, " \t\n") {
+		return fmt.Errorf("invalid target branch %q", update.TargetBranch)
+	}
+	if !slices.Contains(dependabotIntervals, update.Schedule.Interval) {
+		return fmt.Errorf("schedule interval %q must be one of %s", update.Schedule.Interval, strings.Join(dependabotIntervals, ", "))
+	}
+	if update.Schedule.Day != "" {
+		if update.Schedule.Interval != "weekly" {
+			return fmt.Errorf("a schedule day can only be set for weekly updates")
+		}
+		if !slices.Contains(dependabotDays, update.Schedule.Day) {
+			return fmt.Errorf("schedule day %q must be one of %s", update.Schedule.Day, strings.Join(dependabotDays, ", "))
+		}
+	}
+	if update.Schedule.Time != "" && !dependabotTime.MatchString(update.Schedule.Time) {
+		return fmt.Errorf("schedule time %q must be formatted as hh:mm", update.Schedule.Time)
+	}
+	if update.Schedule.Timezone != "" && update.Schedule.Time == "" {
+		return fmt.Errorf("a schedule timezone requires a schedule time")
+	}
+	for _, group := range update.Groups {
+		if group.Name == "" || len(group.Patterns) == 0 {
+			return fmt.Errorf("groups require a name and at least one pattern")
+		}
+		if group.AppliesTo != "version-updates" && group.AppliesTo != "security-updates" {
+			return fmt.Errorf("group %q applies-to %q must be version-updates or security-updates", group.Name, group.AppliesTo)
+		}
+	}
+	for _, ignore := range update.Ignore {
+		if ignore.DependencyName == "" {
+			return fmt.Errorf("ignore rules require a dependency name")
+		}
+	}
+	for _, registry := range update.Registries {
+		if !registries[registry] {
+			return fmt.Errorf("registry %q is not defined", registry)
+		}
+	}
+
+	return nil
+}
+
+func (registry DependabotRegistry) validate() error {
+	if registry.Name == "" || registry.Type == "" || registry.URL == "" {
+		return fmt.Errorf("registries require a name, type and URL")
+	}
+	for _, secret := range []string{registry.PasswordSecret, registry.TokenSecret} {
+		if secret != "" && !dependabotSecretName.MatchString(secret) {
+			return fmt.Errorf("registry %q refers to an invalid secret name %q", registry.Name, secret)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDependabotYmlDirectories(t *testing.T) {
+	tests := []struct {
+		name        string
+		directories []string
+		expected    string
+	}{
+		{
+			name:     "root",
+			expected: "    directory: /\n",
+		},
+		{
+			name:        "one directory",
+			directories: []string{"/crates/hdk"},
+			expected:    "    directory: /crates/hdk\n",
+		},
+		{
+			name:        "several directories",
+			directories: []string{"/crates/hdk", "/crates/hdi"},
+			expected:    "    directories:\n      - \"/crates/hdk\"\n      - \"/crates/hdi\"\n",
+		},
+		{
+			name:        "glob",
+			directories: []string{"/**/*"},
+			expected:    "    directories:\n      - \"/**/*\"\n",
+		},
+		{
+			name:        "brace glob",
+			directories: []string{"/{bindings,crates}/*"},
+			expected:    "    directories:\n      - \"/{bindings,crates}/*\"\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rendered := renderDependabotYml(t, DependabotConfig{Updates: []DependabotUpdate{{Ecosystem: DependabotGomod, Directories: test.directories}}})
+			gomod := rendered[strings.Index(rendered, "  - package-ecosystem: gomod\n"):]
+			if !strings.HasPrefix(gomod, "  - package-ecosystem: gomod\n"+test.expected) {
+				t.Errorf("expected the gomod update to start with\n%s\ngot\n%s", test.expected, gomod)
+			}
+		})
+	}
+}
+
+func TestDependabotYml(t *testing.T) {
+	rendered := renderDependabotYml(t, DependabotConfig{
+		EnableRust: true,
+		Updates: []DependabotUpdate{
+			{
+				Ecosystem:   DependabotGomod,
+				Directories: []string{"/**/*"},
+				Schedule:    DependabotSchedule{Interval: "monthly"},
+				Groups:      []DependabotGroup{{Name: "gomod", Patterns: []string{"*"}}},
+			},
+		},
+	})
+
+	expected := `version: 2
+
+updates:
+  - package-ecosystem: github-actions
+    directory: /
+    schedule:
+      interval: weekly
+    groups:
+      updates:
+        applies-to: version-updates
+        patterns:
+          - "*"
+  - package-ecosystem: cargo
+    directory: /
+    schedule:
+      interval: weekly
+    groups:
+      cargo-minor-patch:
+        applies-to: version-updates
+        patterns:
+          - "*"
+        update-types:
+          - "minor"
+          - "patch"
+  - package-ecosystem: rust-toolchain
+    directory: /
+    schedule:
+      interval: weekly
+  - package-ecosystem: gomod
+    directories:
+      - "/**/*"
+    schedule:
+      interval: monthly
+    groups:
+      gomod:
+        applies-to: version-updates
+        patterns:
+          - "*"
+`
+	if rendered != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, rendered)
+	}
+}
+
+func renderDependabotYml(t *testing.T, config DependabotConfig) string {
+	t.Helper()
+	resolved, err := config.resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered, err := renderSharedFileTemplate(dependabotYmlTemplate, SharedFileData{
+		RepositoryMetadata: RepositoryMetadata{}.withDefaults("example"),
+		Dependabot:         &resolved,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rendered
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// githubRepository is the subset of a repository returned by the REST API that is used here.
+type githubRepository struct {
+	Name                     string    `json:"name"`
+	Description              string    `json:"description"`
+	Visibility               string    `json:"visibility"`
+	Archived                 bool      `json:"archived"`
+	Fork                     bool      `json:"fork"`
+	DefaultBranch            string    `json:"default_branch"`
+	Topics                   []string  `json:"topics"`
+	HasIssues                bool      `json:"has_issues"`
+	HasProjects              bool      `json:"has_projects"`
+	HasWiki                  bool      `json:"has_wiki"`
+	AllowMergeCommit         bool      `json:"allow_merge_commit"`
+	AllowSquashMerge         bool      `json:"allow_squash_merge"`
+	AllowRebaseMerge         bool      `json:"allow_rebase_merge"`
+	AllowAutoMerge           bool      `json:"allow_auto_merge"`
+	AllowUpdateBranch        bool      `json:"allow_update_branch"`
+	DeleteBranchOnMerge      bool      `json:"delete_branch_on_merge"`
+	HasDiscussions           bool      `json:"has_discussions"`
+	IsTemplate               bool      `json:"is_template"`
+	WebCommitSignoffRequired bool      `json:"web_commit_signoff_required"`
+	Homepage                 string    `json:"homepage"`
+	SquashMergeCommitTitle   string    `json:"squash_merge_commit_title"`
+	SquashMergeCommitMessage string    `json:"squash_merge_commit_message"`
+	MergeCommitTitle         string    `json:"merge_commit_title"`
+	MergeCommitMessage       string    `json:"merge_commit_message"`
+	PushedAt                 time.Time `json:"pushed_at"`
+}
+
+// unmanagedRepository is a repository in the `unmanagedRepositories` stack output.
+type unmanagedRepository struct {
+	Name                     string   `pulumi:"name"`
+	Description              string   `pulumi:"description"`
+	Visibility               string   `pulumi:"visibility"`
+	Archived                 bool     `pulumi:"archived"`
+	Fork                     bool     `pulumi:"fork"`
+	DefaultBranch            string   `pulumi:"defaultBranch"`
+	Topics                   []string `pulumi:"topics"`
+	HasIssues                bool     `pulumi:"hasIssues"`
+	HasProjects              bool     `pulumi:"hasProjects"`
+	HasWiki                  bool     `pulumi:"hasWiki"`
+	AllowMergeCommit         bool     `pulumi:"allowMergeCommit"`
+	AllowSquashMerge         bool     `pulumi:"allowSquashMerge"`
+	AllowRebaseMerge         bool     `pulumi:"allowRebaseMerge"`
+	AllowAutoMerge           bool     `pulumi:"allowAutoMerge"`
+	AllowUpdateBranch        bool     `pulumi:"allowUpdateBranch"`
+	DeleteBranchOnMerge      bool     `pulumi:"deleteBranchOnMerge"`
+	HasDiscussions           bool     `pulumi:"hasDiscussions"`
+	IsTemplate               bool     `pulumi:"isTemplate"`
+	WebCommitSignoffRequired bool     `pulumi:"webCommitSignoffRequired"`
+	Homepage                 string   `pulumi:"homepage"`
+	SquashMergeCommitTitle   string   `pulumi:"squashMergeCommitTitle"`
+	SquashMergeCommitMessage string   `pulumi:"squashMergeCommitMessage"`
+	MergeCommitTitle         string   `pulumi:"mergeCommitTitle"`
+	MergeCommitMessage       string   `pulumi:"mergeCommitMessage"`
+	// LastPush is the date of the last push, or empty if the repository has never been pushed to.
+	LastPush string `pulumi:"lastPush"`
+}
+
+func newUnmanagedRepository(repository githubRepository, pushedAt time.Time) unmanagedRepository {
+	unmanaged := unmanagedRepository{
+		Name:                     repository.Name,
+		Description:              repository.Description,
+		Visibility:               repository.Visibility,
+		Archived:                 repository.Archived,
+		Fork:                     repository.Fork,
+		DefaultBranch:            repository.DefaultBranch,
+		Topics:                   repository.Topics,
+		HasIssues:                repository.HasIssues,
+		HasProjects:              repository.HasProjects,
+		HasWiki:                  repository.HasWiki,
+		AllowMergeCommit:         repository.AllowMergeCommit,
+		AllowSquashMerge:         repository.AllowSquashMerge,
+		AllowRebaseMerge:         repository.AllowRebaseMerge,
+		AllowAutoMerge:           repository.AllowAutoMerge,
+		AllowUpdateBranch:        repository.AllowUpdateBranch,
+		DeleteBranchOnMerge:      repository.DeleteBranchOnMerge,
+		HasDiscussions:           repository.HasDiscussions,
+		IsTemplate:               repository.IsTemplate,
+		WebCommitSignoffRequired: repository.WebCommitSignoffRequired,
+		Homepage:                 repository.Homepage,
+		SquashMergeCommitTitle:   repository.SquashMergeCommitTitle,
+		SquashMergeCommitMessage: repository.SquashMergeCommitMessage,
+		MergeCommitTitle:         repository.MergeCommitTitle,
+		MergeCommitMessage:       repository.MergeCommitMessage,
+	}
+	if !pushedAt.IsZero() {
+		unmanaged.LastPush = pushedAt.Format(time.DateOnly)
+	}
+
+	return unmanaged
+}
+
+// organizationRepositories lists the repositories in the organization.
+type organizationRepositories interface {
+	repositories() ([]githubRepository, error)
+	// repository returns the full settings of a repository, which are not all included in the list.
+	repository(listed githubRepository) (githubRepository, error)
+}
+
+// repositories lists every repository in the organization from the GitHub API.
+func (api *githubAPI) repositories() ([]githubRepository, error) {
+	return getAllPages[githubRepository](api, fmt.Sprintf("/orgs/%s/repos?type=all", githubOrganization))
+}
+
+func (api *githubAPI) repository(listed githubRepository) (githubRepository, error) {
+	var repository githubRepository
+	if err := api.do(http.MethodGet, fmt.Sprintf("/repos/%s/%s", githubOrganization, listed.Name), nil, &repository); err != nil {
+		return listed, err
+	}
+
+	return repository, nil
+}
+
+// localRepositories is a stand-in for the GitHub API that reads the repositories from a JSON file
+// in the format returned by `GET /orgs/{org}/repos`, for example one saved with
+// `gh api --paginate orgs/holochain/repos | jq -s add > repositories.json`.
+type localRepositories struct {
+	path string
+}
+
+func (local localRepositories) repositories() ([]githubRepository, error) {
+	content, err := os.ReadFile(local.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []githubRepository
+	if err := json.Unmarshal(content, &repositories); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", local.path, err)
+	}
+
+	return repositories, nil
+}
+
+func (local localRepositories) repository(listed githubRepository) (githubRepository, error) {
+	return listed, nil
+}
+
+// organizationRepositoriesFor returns the source of the organization's repositories, which is the
+// file in `discoveryRepositoriesFile` if it is set, or the GitHub API.
+func organizationRepositoriesFor(ctx *pulumi.Context) (organizationRepositories, error) {
+	if path := config.Get(ctx, "holochain:discoveryRepositoriesFile"); path != "" {
+		return localRepositories{path: path}, nil
+	}
+
+	return newGithubAPI(ctx)
+}
+
+// unmanagedRepositories returns the repositories that are not in managed, most recently pushed first.
+func unmanagedRepositories(repositories []githubRepository, managed []string) []githubRepository {
+	var unmanaged []githubRepository
+	for _, repository := range repositories {
+		if slices.Contains(managed, repository.Name) {
+			continue
+		}
+		unmanaged = append(unmanaged, repository)
+	}
+	slices.SortStableFunc(unmanaged, func(a, b githubRepository) int {
+		return b.PushedAt.Compare(a.PushedAt)
+	})
+
+	return unmanaged
+}
+
+// ExportUnmanagedRepositoriesReport compares the repositories in the organization with the
+// repositories declared by this program, and exports the ones that are not managed as the
+// `unmanagedRepositories` stack output, and as a Markdown table in the
+// `unmanagedRepositoriesReport` stack output. It does nothing unless
+// `discoverUnmanagedRepositories` is enabled, and must be called after all repositories are
+// declared.
+func ExportUnmanagedRepositoriesReport(ctx *pulumi.Context) error {
+	if !config.GetBool(ctx, "holochain:discoverUnmanagedRepositories") || isValidating(ctx) {
+		return nil
+	}
+
+	source, err := organizationRepositoriesFor(ctx)
+	if err != nil {
+		return err
+	}
+	repositories, err := source.repositories()
+	if err != nil {
+		return fmt.Errorf("listing the repositories in %s: %w", githubOrganization, err)
+	}
+
+	var unmanaged []unmanagedRepository
+	for _, listed := range unmanagedRepositories(repositories, stateFor(ctx).managedRepositories) {
+		repository, err := source.repository(listed)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", listed.Name, err)
+		}
+		unmanaged = append(unmanaged, newUnmanagedRepository(repository, listed.PushedAt))
+	}
+	ctx.Export("unmanagedRepositories", pulumi.ToOutput(unmanaged))
+	ctx.Export("unmanagedRepositoriesReport", pulumi.String(unmanagedRepositoriesMarkdown(unmanaged, len(repositories))))
+
+	return nil
+}
+
+func unmanagedRepositoriesMarkdown(unmanaged []unmanagedRepository, total int) string {
+	var report strings.Builder
+	report.WriteString("# Unmanaged repositories\n\n")
+	fmt.Fprintf(&report, "%d of the %d repositories in %s are not managed by hc-github-config.\n", len(unmanaged), total, githubOrganization)
+	if len(unmanaged) == 0 {
+		return report.String()
+	}
+
+	report.WriteString("\n| Repository | Visibility | Last push | Default branch | Archived | Fork | Merge methods |\n")
+	report.WriteString("|------------|------------|-----------|----------------|----------|------|---------------|\n")
+	for _, repository := range unmanaged {
+		var mergeMethods []string
+		if repository.AllowMergeCommit {
+			mergeMethods = append(mergeMethods, "merge")
+		}
+		if repository.AllowSquashMerge {
+			mergeMethods = append(mergeMethods, "squash")
+		}
+		if repository.AllowRebaseMerge {
+			mergeMethods = append(mergeMethods, "rebase")
+		}
+		fmt.Fprintf(&report, "| [%s](https://github.com/%s/%s) | %s | %s | %s | %s | %s | %s |\n",
+			repository.Name, githubOrganization, repository.Name, repository.Visibility, orDash(repository.LastPush),
+			orDash(repository.DefaultBranch), yesNo(repository.Archived), yesNo(repository.Fork), orDash(strings.Join(mergeMethods, ", ")))
+	}
+
+	return report.String()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
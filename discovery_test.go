@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// repositoriesJSON is a saved list of repositories in the format returned by `GET /orgs/{org}/repos`.
+const repositoriesJSON = `[
+  {"name": "lair", "visibility": "public", "default_branch": "main", "pushed_at": "2026-10-01T12:00:00Z", "allow_rebase_merge": true},
+  {"name": "devhub-gui", "visibility": "public", "default_branch": "develop", "archived": true, "pushed_at": "2023-02-14T09:30:00Z", "allow_merge_commit": true, "allow_squash_merge": true},
+  {"name": "app-store-gui", "visibility": "private", "fork": true, "pushed_at": "2024-06-03T18:45:00Z"},
+  {"name": "empty", "visibility": "private"}
+]`
+
+func TestUnmanagedRepositories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repositories.json")
+	if err := os.WriteFile(path, []byte(repositoriesJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := localRepositories{path: path}
+	repositories, err := source.repositories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var unmanaged []unmanagedRepository
+	for _, listed := range unmanagedRepositories(repositories, []string{"lair"}) {
+		repository, err := source.repository(listed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unmanaged = append(unmanaged, newUnmanagedRepository(repository, listed.PushedAt))
+	}
+
+	expected := `# Unmanaged repositories
+
+3 of the 4 repositories in holochain are not managed by hc-github-config.
+
+| Repository | Visibility | Last push | Default branch | Archived | Fork | Merge methods |
+|------------|------------|-----------|----------------|----------|------|---------------|
+| [app-store-gui](https://github.com/holochain/app-store-gui) | private | 2024-06-03 | - | no | yes | - |
+| [devhub-gui](https://github.com/holochain/devhub-gui) | public | 2023-02-14 | develop | yes | no | merge, squash |
+| [empty](https://github.com/holochain/empty) | private | - | - | no | no | - |
+`
+	if report := unmanagedRepositoriesMarkdown(unmanaged, len(repositories)); report != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, report)
+	}
+}
+
+func TestUnmanagedRepositoriesAllManaged(t *testing.T) {
+	unmanaged := unmanagedRepositories([]githubRepository{{Name: "lair"}}, []string{"lair"})
+	if len(unmanaged) != 0 {
+		t.Errorf("expected no unmanaged repositories, got %v", unmanaged)
+	}
+
+	expected := "# Unmanaged repositories\n\n0 of the 1 repositories in holochain are not managed by hc-github-config.\n"
+	if report := unmanagedRepositoriesMarkdown(nil, 1); report != expected {
+		t.Errorf("expected %q, got %q", expected, report)
+	}
+}
+
+func TestLocalRepositoriesInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repositories.json")
+	if err := os.WriteFile(path, []byte(`{"name": "lair"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (localRepositories{path: path}).repositories(); err == nil {
+		t.Error("expected an error for a file that isn't a list of repositories")
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// SharedFileStatus compares a shared file on a repository's default branch with the current template.
+type SharedFileStatus string
+
+const (
+	SharedFileUpToDate SharedFileStatus = "up-to-date"
+	SharedFileOutdated SharedFileStatus = "outdated"
+	SharedFileMissing  SharedFileStatus = "missing"
+	// SharedFileUnknown is used when the file could not be read.
+	SharedFileUnknown SharedFileStatus = "unknown"
+)
+
+// SharedFilesDrift is the state of the shared files and their update PR for one repository.
+type SharedFilesDrift struct {
+	Repository string            `pulumi:"repository"`
+	Files      []SharedFileDrift `pulumi:"files"`
+	// PullRequest is the number of the update PR.
+	PullRequest int `pulumi:"pullRequest"`
+	// PullRequestState is `open` or `closed`.
+	PullRequestState string `pulumi:"pullRequestState"`
+	// OpenedAt is when the update PR was opened, as a Unix timestamp.
+	OpenedAt int `pulumi:"openedAt"`
+	// OpenDays is how many days the update PR has been open, if it is still open.
+	OpenDays int `pulumi:"openDays"`
+}
+
+// SharedFileDrift is the state of one shared file on the default branch.
+type SharedFileDrift struct {
+	Path   string           `pulumi:"path"`
+	Status SharedFileStatus `pulumi:"status"`
+}
+
+// drifted reports whether any of the files differ from the current template.
+func (drift SharedFilesDrift) drifted() bool {
+	return slices.ContainsFunc(drift.Files, func(file SharedFileDrift) bool {
+		return file.Status != SharedFileUpToDate
+	})
+}
+
+// trackSharedFilesDrift compares the files on the default branch of the repository with the
+// rendered files, and records the result along with the state of the update PR for
+// ExportSharedFilesDriftReport. It does nothing unless `reportSharedFilesDrift` is enabled,
+// because it reads every shared file from GitHub on each run.
+func trackSharedFilesDrift(ctx *pulumi.Context, name string, baseBranch string, files []SharedFile, pullRequest *github.RepositoryPullRequest) {
+	if !config.GetBool(ctx, "holochain:reportSharedFilesDrift") || isValidating(ctx) {
+		return
+	}
+
+	api, err := newGithubAPI(ctx)
+	if err != nil {
+		_ = ctx.Log.Warn(fmt.Sprintf("unable to check the shared files of %s for drift: %v", name, err), nil)
+		return
+	}
+	fileDrift := make([]SharedFileDrift, 0, len(files))
+	for _, file := range files {
+		fileDrift = append(fileDrift, SharedFileDrift{Path: file.Path, Status: sharedFileStatus(ctx, api, name, baseBranch, file)})
+	}
+
+	drift := pullRequest.Number.ApplyT(func(number int) (SharedFilesDrift, error) {
+		drift := SharedFilesDrift{Repository: name, Files: fileDrift, PullRequest: number}
+
+		result, err := github.LookupRepositoryPullRequest(ctx, &github.LookupRepositoryPullRequestArgs{
+			BaseRepository: name,
+			Number:         number,
+		})
+		if err != nil {
+			return drift, fmt.Errorf("reading shared files PR #%d for %s: %w", number, name, err)
+		}
+
+		drift.PullRequestState = result.State
+		drift.OpenedAt = result.OpenedAt
+		if result.State == "open" {
+			drift.OpenDays = int(time.Since(time.Unix(int64(result.OpenedAt), 0)).Hours() / 24)
+		}
+
+		return drift, nil
+	})
+
+	state := stateFor(ctx)
+	state.sharedFilesDrift = append(state.sharedFilesDrift, drift)
+}
+
+func sharedFileStatus(ctx *pulumi.Context, api *githubAPI, name string, baseBranch string, file SharedFile) SharedFileStatus {
+	current, err := api.fileContent(name, file.Path, baseBranch)
+	switch {
+	case errors.Is(err, errGithubNotFound):
+		return SharedFileMissing
+	case err != nil:
+		_ = ctx.Log.Warn(fmt.Sprintf("unable to read %s from %s: %v", file.Path, name, err), nil)
+		return SharedFileUnknown
+	case current != file.Content:
+		return SharedFileOutdated
+	default:
+		return SharedFileUpToDate
+	}
+}
+
+// ExportSharedFilesDriftReport exports the repositories whose shared files differ from the current
+// templates as the `sharedFilesDrift` stack output, and as a Markdown table in the
+// `sharedFilesDriftReport` stack output. It must be called after all repositories are declared.
+func ExportSharedFilesDriftReport(ctx *pulumi.Context) {
+	outputs := stateFor(ctx).sharedFilesDrift
+	if len(outputs) == 0 {
+		return
+	}
+
+	inputs := make([]interface{}, len(outputs))
+	for i, output := range outputs {
+		inputs[i] = output
+	}
+
+	report := pulumi.All(inputs...).ApplyT(func(results []interface{}) []SharedFilesDrift {
+		var drifted []SharedFilesDrift
+		for _, result := range results {
+			if drift := result.(SharedFilesDrift); drift.drifted() {
+				drifted = append(drifted, drift)
+			}
+		}
+		slices.SortFunc(drifted, func(a, b SharedFilesDrift) int {
+			return strings.Compare(a.Repository, b.Repository)
+		})
+
+		return drifted
+	})
+
+	ctx.Export("sharedFilesDrift", report)
+	// The report is an AnyOutput, so its value has to be asserted back to the type returned above.
+	ctx.Export("sharedFilesDriftReport", report.ApplyT(func(drifted interface{}) string {
+		return sharedFilesDriftMarkdown(drifted.([]SharedFilesDrift))
+	}))
+}
+
+func sharedFilesDriftMarkdown(drifted []SharedFilesDrift) string {
+	var report strings.Builder
+	report.WriteString("# Shared files drift\n\n")
+	if len(drifted) == 0 {
+		report.WriteString("All shared files are up to date.\n")
+		return report.String()
+	}
+
+	report.WriteString("| Repository | Files | Update PR | Open for |\n")
+	report.WriteString("|------------|-------|-----------|----------|\n")
+	for _, drift := range drifted {
+		var files []string
+		for _, file := range drift.Files {
+			if file.Status != SharedFileUpToDate {
+				files = append(files, fmt.Sprintf("`%s` (%s)", file.Path, file.Status))
+			}
+		}
+
+		openFor := "-"
+		if drift.PullRequestState == "open" {
+			openFor = fmt.Sprintf("%d days", drift.OpenDays)
+		}
+
+		fmt.Fprintf(&report, "| %s | %s | [#%d](https://github.com/holochain/%s/pull/%d) (%s) | %s |\n",
+			drift.Repository, strings.Join(files, ", "), drift.PullRequest, drift.Repository, drift.PullRequest, drift.PullRequestState, openFor)
+	}
+
+	return report.String()
+}
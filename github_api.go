@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// githubOrganization is the organization that all managed repositories belong to.
+const githubOrganization = "holochain"
+
+// errGithubNotFound is wrapped by the errors of requests that GitHub answers with 404 Not Found,
+// so that callers can check for a missing file or repository with errors.Is.
+var errGithubNotFound = errors.New(http.StatusText(http.StatusNotFound))
+
+// providerNotFound matches the error of a provider lookup that GitHub answered with 404 Not Found,
+// such as `GET https://api.github.com/repos/holochain/lair/branches/main-0.5: 404 Branch not found []`.
+var providerNotFound = regexp.MustCompile(`\bGET https://\S+: 404\b`)
+
+// lookupError returns the error of a provider lookup, wrapping errGithubNotFound if GitHub answered
+// with 404 Not Found, so that it can be checked with errors.Is like the errors of githubAPI.
+func lookupError(err error) error {
+	if err != nil && providerNotFound.MatchString(err.Error()) {
+		return fmt.Errorf("%w: %w", errGithubNotFound, err)
+	}
+
+	return err
+}
+
+// githubAPI calls the GitHub API directly, for the few operations that the Pulumi provider
+// does not manage, such as enabling auto-merge on a pull request.
+type githubAPI struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// newGithubAPI returns a client that authenticates with the same token as the Pulumi provider,
+// from the `github:token` config or the GITHUB_TOKEN environment variable.
+func newGithubAPI(ctx *pulumi.Context) (*githubAPI, error) {
+	token := config.Get(ctx, "github:token")
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("a GitHub token is required, set github:token or GITHUB_TOKEN")
+	}
+
+	return newGithubAPIWithToken(token), nil
+}
+
+// newGithubAPIWithToken returns a client for use outside a Pulumi program.
+func newGithubAPIWithToken(token string) *githubAPI {
+	return &githubAPI{
+		baseURL: "https://api.github.com",
+		token:   token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// githubPullRequest is the subset of a pull request returned by the REST API that is used here.
+type githubPullRequest struct {
+	Number         int           `json:"number"`
+	NodeID         string        `json:"node_id"`
+	State          string        `json:"state"`
+	Labels         []githubLabel `json:"labels"`
+	RequestedTeams []githubTeam  `json:"requested_teams"`
+	// AutoMerge is set when auto-merge is enabled.
+	AutoMerge *struct {
+		MergeMethod string `json:"merge_method"`
+	} `json:"auto_merge"`
+}
+
+// githubTeam is a team that a review is requested from.
+type githubTeam struct {
+	Slug string `json:"slug"`
+}
+
+func (pullRequest githubPullRequest) hasLabel(label string) bool {
+	return slices.Contains(pullRequest.Labels, githubLabel{Name: label})
+}
+
+func (pullRequest githubPullRequest) hasRequestedTeam(team string) bool {
+	return slices.Contains(pullRequest.RequestedTeams, githubTeam{Slug: team})
+}
+
+func (api *githubAPI) pullRequest(repository string, number int) (githubPullRequest, error) {
+	var pullRequest githubPullRequest
+	err := api.do(http.MethodGet, fmt.Sprintf("/repos/%s/%s/pulls/%d", githubOrganization, repository, number), nil, &pullRequest)
+	return pullRequest, err
+}
+
+// openPullRequests lists the open pull requests whose head is the given branch.
+func (api *githubAPI) openPullRequests(repository string, head string) ([]githubPullRequest, error) {
+	var pullRequests []githubPullRequest
+	err := api.do(http.MethodGet, fmt.Sprintf("/repos/%s/%s/pulls?state=open&per_page=100&head=%s:%s", githubOrganization, repository, githubOrganization, head), nil, &pullRequests)
+	return pullRequests, err
+}
+
+// closedPullRequests lists the closed pull requests whose head is the given branch.
+func (api *githubAPI) closedPullRequests(repository string, head string) ([]githubPullRequest, error) {
+	var pullRequests []githubPullRequest
+	err := api.do(http.MethodGet, fmt.Sprintf("/repos/%s/%s/pulls?state=closed&per_page=100&head=%s:%s", githubOrganization, repository, githubOrganization, head), nil, &pullRequests)
+	return pullRequests, err
+}
+
+func (api *githubAPI) addLabels(repository string, number int, labels ...string) error {
+	return api.do(http.MethodPost, fmt.Sprintf("/repos/%s/%s/issues/%d/labels", githubOrganization, repository, number), map[string]any{"labels": labels}, nil)
+}
+
+func (api *githubAPI) requestTeamReview(repository string, number int, teams ...string) error {
+	return api.do(http.MethodPost, fmt.Sprintf("/repos/%s/%s/pulls/%d/requested_reviewers", githubOrganization, repository, number), map[string]any{"team_reviewers": teams}, nil)
+}
+
+func (api *githubAPI) comment(repository string, number int, body string) error {
+	return api.do(http.MethodPost, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", githubOrganization, repository, number), map[string]any{"body": body}, nil)
+}
+
+func (api *githubAPI) closePullRequest(repository string, number int) error {
+	return api.do(http.MethodPatch, fmt.Sprintf("/repos/%s/%s/pulls/%d", githubOrganization, repository, number), map[string]any{"state": "closed"}, nil)
+}
+
+// enableAutoMerge enables auto-merge on a pull request, which is only available through GraphQL.
+// GitHub merges the pull request once the required status checks and reviews have passed.
+func (api *githubAPI) enableAutoMerge(pullRequest githubPullRequest, mergeMethod string) error {
+	query := map[string]any{
+		"query": `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId }
+}`,
+		"variables": map[string]any{"id": pullRequest.NodeID, "method": mergeMethod},
+	}
+
+	var response struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := api.do(http.MethodPost, "/graphql", query, &response); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("enabling auto-merge on #%d: %s", pullRequest.Number, response.Errors[0].Message)
+	}
+
+	return nil
+}
+
+// githubContent is a file or directory returned by the contents API.
+type githubContent struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// fileContent reads a file from a branch of a repository, or from the default branch if branch is
+// empty. The error wraps errGithubNotFound if the file doesn't exist.
+func (api *githubAPI) fileContent(repository string, path string, branch string) (string, error) {
+	path = fmt.Sprintf("/repos/%s/%s/contents/%s", githubOrganization, repository, path)
+	if branch != "" {
+		path += "?ref=" + url.QueryEscape(branch)
+	}
+	var file githubContent
+	if err := api.do(http.MethodGet, path, nil, &file); err != nil {
+		return "", err
+	}
+	content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return "", fmt.Errorf("decoding %s: %w", file.Path, err)
+	}
+
+	return string(content), nil
+}
+
+// getAllPages reads every page of a list from the GitHub API.
+func getAllPages[T any](api *githubAPI, path string) ([]T, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	var all []T
+	for page := 1; ; page++ {
+		var items []T
+		if err := api.do(http.MethodGet, fmt.Sprintf("%s%sper_page=100&page=%d", path, separator, page), nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < 100 {
+			return all, nil
+		}
+	}
+}
+
+func (api *githubAPI) do(method string, path string, body any, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequest(method, api.baseURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("Authorization", "Bearer "+api.token)
+	request.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	response, err := api.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		if response.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%s %s: %w: %s", method, path, errGithubNotFound, bytes.TrimSpace(message))
+		}
+		return fmt.Errorf("%s %s: %s: %s", method, path, response.Status, bytes.TrimSpace(message))
+	}
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGithubAPIFileContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.String() {
+		case "/repos/holochain/lair/contents/SECURITY.md?ref=release-0.6":
+			_, _ = w.Write([]byte(`{"path": "SECURITY.md", "type": "file", "encoding": "base64", "content": "IyBTZWN1\ncml0eQo=\n"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	}))
+	defer server.Close()
+	api := newGithubAPIWithToken("token")
+	api.baseURL = server.URL
+
+	content, err := api.fileContent("lair", "SECURITY.md", "release-0.6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "# Security\n" {
+		t.Errorf("expected the decoded file, got %q", content)
+	}
+
+	_, err = api.fileContent("lair", "SECURITY.md", "main")
+	if !errors.Is(err, errGithubNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestLookupError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		notFound bool
+	}{
+		{name: "no error"},
+		{
+			name:     "not found",
+			err:      errors.New("invoking github:index/getBranch:getBranch: GET https://api.github.com/repos/holochain/lair/branches/main-0.5: 404 Branch not found []"),
+			notFound: true,
+		},
+		{
+			name: "other status",
+			err:  errors.New("invoking github:index/getRepositoryFile:getRepositoryFile: GET https://api.github.com/repos/holochain/lair/contents/README.md: 502 Bad Gateway []"),
+		},
+		{
+			name: "404 elsewhere in the message",
+			err:  errors.New("invoking github:index/getRepository:getRepository: rate limited after 404 requests"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := lookupError(test.err)
+			if errors.Is(err, errGithubNotFound) != test.notFound {
+				t.Errorf("expected not found to be %t, got %v", test.notFound, err)
+			}
+			if test.err != nil && !errors.Is(err, test.err) {
+				t.Errorf("expected %v to wrap %v", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// AddGithubApp lets the workflows of a repository mint short-lived tokens for the automation GitHub
+// App, instead of using a personal access token from AddGithubToken. The App's ID is stored as the
+// HRA2_APP_ID variable and its private key as the HRA2_APP_PRIVATE_KEY secret, for use with
+// `actions/create-github-app-token`, and the repository is added to the App's installation by
+// SyncGithubAppInstallation.
+func AddGithubApp(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
+	}
+
+	state := stateFor(ctx)
+	if slices.Contains(state.githubAppRepositories, repository) {
+		return nil
+	}
+	state.githubAppRepositories = append(state.githubAppRepositories, repository)
+
+	_, err := github.NewActionsVariable(ctx, fmt.Sprintf("%s-app-id", repository), &github.ActionsVariableArgs{
+		Repository:   pulumi.String(repository),
+		VariableName: pulumi.String("HRA2_APP_ID"),
+		Value:        pulumi.String(requireConfig(ctx, "", repository, "githubAppId")),
+	}, InRepository(ctx, repository))
+	if err != nil {
+		return err
+	}
+
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-app-private-key", repository),
+		SecretName:   "HRA2_APP_PRIVATE_KEY",
+		Key:          "githubAppPrivateKey",
+	})
+}
+
+// SyncGithubAppInstallation sets the repositories that the automation GitHub App is installed on to
+// the repositories added with AddGithubApp, so that the App can't mint tokens for any other
+// repository. It must be called after all repositories are declared, and does nothing until a
+// repository uses the App.
+func SyncGithubAppInstallation(ctx *pulumi.Context) error {
+	repositories := githubAppInstallationRepositories(ctx)
+	if len(repositories) == 0 {
+		return nil
+	}
+
+	// The repositories must exist before they can be added to the installation.
+	var dependencies []pulumi.Resource
+	for _, repository := range repositories {
+		if component, ok := stateFor(ctx).repositoryComponents[repository]; ok {
+			dependencies = append(dependencies, component.Repository)
+		}
+	}
+
+	_, err := github.NewAppInstallationRepositories(ctx, "github-app-installation", &github.AppInstallationRepositoriesArgs{
+		InstallationId:       pulumi.String(requireConfig(ctx, "", "", "githubAppInstallationId")),
+		SelectedRepositories: pulumi.ToStringArray(repositories),
+	}, pulumi.DependsOn(dependencies))
+
+	return err
+}
+
+// githubAppInstallationRepositories are the repositories that the App's installation is limited to.
+func githubAppInstallationRepositories(ctx *pulumi.Context) []string {
+	repositories := slices.Clone(stateFor(ctx).githubAppRepositories)
+	slices.Sort(repositories)
+
+	return repositories
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestSyncGithubAppInstallation(t *testing.T) {
+	tests := []struct {
+		name         string
+		repositories []string
+		expected     []string
+	}{
+		{
+			name: "no repository uses the App",
+		},
+		{
+			name:         "sorted without duplicates",
+			repositories: []string{"tx5", "lair", "tx5", "holonix"},
+			expected:     []string{"holonix", "lair", "tx5"},
+		},
+	}
+
+	configuration := map[string]string{
+		"holochain:githubAppId":             "1234",
+		"holochain:githubAppInstallationId": "5678",
+		"holochain:githubAppPrivateKey":     "private-key",
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var selected []string
+			state, duplicates, err := declareWithMocks("holochain", "github", configuration, func(ctx *pulumi.Context) error {
+				for _, repository := range test.repositories {
+					if err := AddGithubApp(ctx, repository); err != nil {
+						return err
+					}
+				}
+				if err := SyncGithubAppInstallation(ctx); err != nil {
+					return err
+				}
+				selected = githubAppInstallationRepositories(ctx)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(selected, test.expected) {
+				t.Errorf("expected the selected repositories %v, got %v", test.expected, selected)
+			}
+			if problems := append(state.validationErrors, duplicates...); len(problems) > 0 {
+				t.Errorf("expected no problems, got %v", problems)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// GithubTokenTier is the access of the GitHub token that a repository is given as HRA2_GITHUB_TOKEN.
+type GithubTokenTier string
+
+const (
+	// GithubTokenUser has standard repository access, and is used on most repositories.
+	GithubTokenUser GithubTokenTier = "user"
+	// GithubTokenWorkflows has standard repository access, and can edit and control workflows.
+	GithubTokenWorkflows GithubTokenTier = "workflows"
+	// GithubTokenAdmin has admin access to other repositories, and is only meant for this repository.
+	GithubTokenAdmin GithubTokenTier = "admin"
+)
+
+// githubTokenKeys are the configuration keys of the token for each tier.
+var githubTokenKeys = map[GithubTokenTier]string{
+	GithubTokenUser:      "hra2GithubUserToken",
+	GithubTokenWorkflows: "hra2GithubWorkflowsToken",
+	GithubTokenAdmin:     "hra2GithubAdminToken",
+}
+
+// GithubTokenConfig selects the tier of the HRA2_GITHUB_TOKEN secret in each scope of a repository.
+// A scope without a tier doesn't get the secret.
+type GithubTokenConfig struct {
+	Actions    GithubTokenTier
+	Dependabot GithubTokenTier
+}
+
+// githubTokenResourceNames are the names of the secret resources in each scope, which don't depend
+// on the tier so that changing the tier updates the secret rather than adding a second one.
+var githubTokenResourceNames = map[SecretScope]string{
+	SecretScopeActions:    "%s-github-token",
+	SecretScopeDependabot: "%s-dependabot-github-token",
+}
+
+// AddGithubToken stores the GitHub token of the selected tier as HRA2_GITHUB_TOKEN in each scope of a
+// repository. It can be called more than once for a repository, for example by AddReleaseIntegrationSupport,
+// as long as every call selects the same tier for a scope.
+func AddGithubToken(ctx *pulumi.Context, repository string, tokens GithubTokenConfig) error {
+	if isRetired(repository) {
+		return nil
+	}
+
+	state := stateFor(ctx)
+	declared := state.githubTokens[repository]
+	for _, scope := range []SecretScope{SecretScopeActions, SecretScopeDependabot} {
+		tier, declaredTier := tokens.Actions, &declared.Actions
+		if scope == SecretScopeDependabot {
+			tier, declaredTier = tokens.Dependabot, &declared.Dependabot
+		}
+		if tier == "" || tier == *declaredTier {
+			continue
+		}
+		key, ok := githubTokenKeys[tier]
+		if !ok {
+			invalid(ctx, repository, "unknown GitHub token tier %q for %s", tier, scope)
+			continue
+		}
+		if *declaredTier != "" {
+			invalid(ctx, repository, "HRA2_GITHUB_TOKEN for %s is given both the %s and the %s GitHub token", scope, *declaredTier, tier)
+			continue
+		}
+		*declaredTier = tier
+
+		if err := deploySecret(ctx, SecretDeployment{
+			Repository:   repository,
+			Scope:        scope,
+			ResourceName: fmt.Sprintf(githubTokenResourceNames[scope], repository),
+			SecretName:   "HRA2_GITHUB_TOKEN",
+			Key:          key,
+		}); err != nil {
+			return err
+		}
+	}
+	state.githubTokens[repository] = declared
+
+	return nil
+}
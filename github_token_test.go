@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestAddGithubToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokens      []GithubTokenConfig
+		deployments []string
+		expected    ValidationErrors
+	}{
+		{
+			name:        "same tier twice",
+			tokens:      []GithubTokenConfig{{Actions: GithubTokenUser}, {Actions: GithubTokenUser, Dependabot: GithubTokenUser}},
+			deployments: []string{"actions holochain:hra2GithubUserToken", "dependabot holochain:hra2GithubUserToken"},
+		},
+		{
+			name:        "conflicting tiers",
+			tokens:      []GithubTokenConfig{{Actions: GithubTokenUser}, {Actions: GithubTokenWorkflows}},
+			deployments: []string{"actions holochain:hra2GithubUserToken"},
+			expected: ValidationErrors{
+				{Repository: "lair", Problem: "HRA2_GITHUB_TOKEN for actions is given both the user and the workflows GitHub token"},
+			},
+		},
+		{
+			name:   "unknown tier",
+			tokens: []GithubTokenConfig{{Dependabot: "owner"}},
+			expected: ValidationErrors{
+				{Repository: "lair", Problem: `unknown GitHub token tier "owner" for dependabot`},
+			},
+		},
+	}
+
+	configuration := map[string]string{
+		"holochain:hra2GithubUserToken":      "user",
+		"holochain:hra2GithubWorkflowsToken": "workflows",
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, _, err := declareWithMocks("holochain", "github", configuration, func(ctx *pulumi.Context) error {
+				for _, tokens := range test.tokens {
+					if err := AddGithubToken(ctx, "lair", tokens); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var deployments []string
+			for _, deployment := range state.secretDeployments {
+				deployments = append(deployments, string(deployment.Scope)+" "+deployment.Key)
+			}
+			if !reflect.DeepEqual(deployments, test.deployments) {
+				t.Errorf("expected the deployments %v, got %v", test.deployments, deployments)
+			}
+			if !reflect.DeepEqual(state.validationErrors, test.expected) {
+				t.Errorf("expected the problems %v, got %v", test.expected, state.validationErrors)
+			}
+		})
+	}
+}
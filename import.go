@@ -0,0 +1,880 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"maps"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// ScaffoldImportCommand is the command that prints the declaration for importing a repository, run
+// with `go run . scaffold-import <repository>`.
+const ScaffoldImportCommand = "scaffold-import"
+
+// runScaffoldImport reads an existing repository from the GitHub API and prints the declaration
+// that imports it without changes, followed by how it differs from the standard configuration.
+func runScaffoldImport(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: go run . %s <repository>", ScaffoldImportCommand)
+	}
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return fmt.Errorf("GITHUB_TOKEN must be set, for example with GITHUB_TOKEN=$(gh auth token)")
+	}
+
+	imported, err := newGithubAPIWithToken(token).importedRepository(args[0])
+	if err != nil {
+		return err
+	}
+	scaffold, err := renderImportScaffold(imported, time.Now().Format(time.DateOnly))
+	if err != nil {
+		return err
+	}
+	fmt.Print(scaffold)
+
+	return nil
+}
+
+// importedRepository is everything about an existing repository that a declaration covers.
+type importedRepository struct {
+	Settings githubRepository
+	Teams    []githubTeamAccess
+	Rulesets []githubRuleset
+	Labels   []githubLabel
+	// Pages is nil if GitHub Pages is not enabled.
+	Pages *githubPages
+}
+
+type githubTeamAccess struct {
+	Slug       string `json:"slug"`
+	Permission string `json:"permission"`
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+type githubPages struct {
+	BuildType string `json:"build_type"`
+	Source    *struct {
+		Branch string `json:"branch"`
+		Path   string `json:"path"`
+	} `json:"source"`
+	Cname string `json:"cname"`
+}
+
+type githubRuleset struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Target      string `json:"target"`
+	SourceType  string `json:"source_type"`
+	Enforcement string `json:"enforcement"`
+	Conditions  struct {
+		RefName struct {
+			Include []string `json:"include"`
+			Exclude []string `json:"exclude"`
+		} `json:"ref_name"`
+	} `json:"conditions"`
+	Rules []struct {
+		Type       string          `json:"type"`
+		Parameters json.RawMessage `json:"parameters"`
+	} `json:"rules"`
+	BypassActors []rulesetBypassActor `json:"bypass_actors"`
+}
+
+func (api *githubAPI) importedRepository(name string) (importedRepository, error) {
+	imported := importedRepository{}
+	repositoryPath := fmt.Sprintf("/repos/%s/%s", githubOrganization, name)
+
+	if err := api.do(http.MethodGet, repositoryPath, nil, &imported.Settings); err != nil {
+		return imported, err
+	}
+
+	var err error
+	if imported.Teams, err = getAllPages[githubTeamAccess](api, repositoryPath+"/teams"); err != nil {
+		return imported, err
+	}
+	if imported.Labels, err = getAllPages[githubLabel](api, repositoryPath+"/labels"); err != nil {
+		return imported, err
+	}
+
+	rulesets, err := getAllPages[githubRuleset](api, repositoryPath+"/rulesets?includes_parents=false")
+	if err != nil {
+		return imported, err
+	}
+	for _, listed := range rulesets {
+		// The list doesn't include the rules, so each ruleset is read separately.
+		var ruleset githubRuleset
+		if err := api.do(http.MethodGet, fmt.Sprintf("%s/rulesets/%d", repositoryPath, listed.ID), nil, &ruleset); err != nil {
+			return imported, err
+		}
+		if ruleset.SourceType == "Repository" {
+			imported.Rulesets = append(imported.Rulesets, ruleset)
+		}
+	}
+
+	var pages githubPages
+	if err := api.do(http.MethodGet, repositoryPath+"/pages", nil, &pages); err == nil {
+		imported.Pages = &pages
+	} else if !errors.Is(err, errGithubNotFound) {
+		return imported, err
+	}
+
+	return imported, nil
+}
+
+// rulesetDeclaration is a ruleset in the shape of github.RepositoryRulesetArgs, so that rulesets
+// read from the API can be compared with the standard rulesets and rendered as Go.
+type rulesetDeclaration struct {
+	Name                  string
+	Target                string
+	Enforcement           string
+	Includes              []string
+	Excludes              []string
+	Creation              bool
+	Update                bool
+	Deletion              bool
+	RequiredLinearHistory bool
+	RequiredSignatures    bool
+	NonFastForward        bool
+	PullRequest           *rulesetPullRequest
+	RequiredStatusChecks  *rulesetRequiredStatusChecks
+	BypassActors          []rulesetBypassActor
+	// Unsupported are the types of rules that are not rendered, and must be added by hand.
+	Unsupported []string
+}
+
+type rulesetPullRequest struct {
+	DismissStaleReviewsOnPush      bool `json:"dismiss_stale_reviews_on_push"`
+	RequireCodeOwnerReview         bool `json:"require_code_owner_review"`
+	RequireLastPushApproval        bool `json:"require_last_push_approval"`
+	RequiredApprovingReviewCount   int  `json:"required_approving_review_count"`
+	RequiredReviewThreadResolution bool `json:"required_review_thread_resolution"`
+}
+
+type rulesetRequiredStatusChecks struct {
+	RequiredChecks                   []rulesetRequiredCheck `json:"required_status_checks"`
+	StrictRequiredStatusChecksPolicy bool                   `json:"strict_required_status_checks_policy"`
+	DoNotEnforceOnCreate             bool                   `json:"do_not_enforce_on_create"`
+}
+
+type rulesetRequiredCheck struct {
+	Context       string `json:"context"`
+	IntegrationID *int   `json:"integration_id"`
+}
+
+type rulesetBypassActor struct {
+	ActorID    *int   `json:"actor_id"`
+	ActorType  string `json:"actor_type"`
+	BypassMode string `json:"bypass_mode"`
+}
+
+func (ruleset githubRuleset) declaration() (rulesetDeclaration, error) {
+	declaration := rulesetDeclaration{
+		Name:         ruleset.Name,
+		Target:       ruleset.Target,
+		Enforcement:  ruleset.Enforcement,
+		Includes:     ruleset.Conditions.RefName.Include,
+		Excludes:     ruleset.Conditions.RefName.Exclude,
+		BypassActors: ruleset.BypassActors,
+	}
+	for _, rule := range ruleset.Rules {
+		var err error
+		switch rule.Type {
+		case "creation":
+			declaration.Creation = true
+		case "update":
+			declaration.Update = true
+		case "deletion":
+			declaration.Deletion = true
+		case "required_linear_history":
+			declaration.RequiredLinearHistory = true
+		case "required_signatures":
+			declaration.RequiredSignatures = true
+		case "non_fast_forward":
+			declaration.NonFastForward = true
+		case "pull_request":
+			declaration.PullRequest = &rulesetPullRequest{}
+			err = json.Unmarshal(rule.Parameters, declaration.PullRequest)
+		case "required_status_checks":
+			declaration.RequiredStatusChecks = &rulesetRequiredStatusChecks{}
+			err = json.Unmarshal(rule.Parameters, declaration.RequiredStatusChecks)
+		default:
+			declaration.Unsupported = append(declaration.Unsupported, rule.Type)
+		}
+		if err != nil {
+			return declaration, fmt.Errorf("reading the %s rule of ruleset %q: %w", rule.Type, ruleset.Name, err)
+		}
+	}
+
+	return declaration, nil
+}
+
+// rulesetDeclarationFromArgs reads the values that the standard rulesets are built from.
+func rulesetDeclarationFromArgs(args github.RepositoryRulesetArgs) rulesetDeclaration {
+	declaration := rulesetDeclaration{
+		Name:        inputString(args.Name),
+		Target:      inputString(args.Target),
+		Enforcement: inputString(args.Enforcement),
+	}
+	if conditions, ok := args.Conditions.(*github.RepositoryRulesetConditionsArgs); ok && conditions != nil {
+		if refName, ok := conditions.RefName.(*github.RepositoryRulesetConditionsRefNameArgs); ok && refName != nil {
+			declaration.Includes = inputStrings(refName.Includes)
+			declaration.Excludes = inputStrings(refName.Excludes)
+		}
+	}
+	if rules, ok := args.Rules.(*github.RepositoryRulesetRulesArgs); ok && rules != nil {
+		declaration.Creation = inputBool(rules.Creation)
+		declaration.Update = inputBool(rules.Update)
+		declaration.Deletion = inputBool(rules.Deletion)
+		declaration.RequiredLinearHistory = inputBool(rules.RequiredLinearHistory)
+		declaration.RequiredSignatures = inputBool(rules.RequiredSignatures)
+		if pullRequest, ok := rules.PullRequest.(*github.RepositoryRulesetRulesPullRequestArgs); ok && pullRequest != nil {
+			declaration.PullRequest = &rulesetPullRequest{
+				DismissStaleReviewsOnPush:      inputBool(pullRequest.DismissStaleReviewsOnPush),
+				RequireCodeOwnerReview:         inputBool(pullRequest.RequireCodeOwnerReview),
+				RequireLastPushApproval:        inputBool(pullRequest.RequireLastPushApproval),
+				RequiredApprovingReviewCount:   inputInt(pullRequest.RequiredApprovingReviewCount),
+				RequiredReviewThreadResolution: inputBool(pullRequest.RequiredReviewThreadResolution),
+			}
+		}
+		if statusChecks, ok := rules.RequiredStatusChecks.(*github.RepositoryRulesetRulesRequiredStatusChecksArgs); ok && statusChecks != nil {
+			declaration.RequiredStatusChecks = &rulesetRequiredStatusChecks{
+				StrictRequiredStatusChecksPolicy: inputBool(statusChecks.StrictRequiredStatusChecksPolicy),
+				DoNotEnforceOnCreate:             inputBool(statusChecks.DoNotEnforceOnCreate),
+			}
+			checks, _ := statusChecks.RequiredChecks.(github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArray)
+			for _, input := range checks {
+				var check github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs
+				switch input := input.(type) {
+				case github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs:
+					check = input
+				case *github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs:
+					check = *input
+				}
+				declaration.RequiredStatusChecks.RequiredChecks = append(declaration.RequiredStatusChecks.RequiredChecks, rulesetRequiredCheck{
+					Context:       inputString(check.Context),
+					IntegrationID: inputIntPtr(check.IntegrationId),
+				})
+			}
+		}
+	}
+	actors, _ := args.BypassActors.(github.RepositoryRulesetBypassActorArray)
+	for _, input := range actors {
+		if actor, ok := input.(*github.RepositoryRulesetBypassActorArgs); ok {
+			declaration.BypassActors = append(declaration.BypassActors, rulesetBypassActor{
+				ActorID:    inputIntPtr(actor.ActorId),
+				ActorType:  inputString(actor.ActorType),
+				BypassMode: inputString(actor.BypassMode),
+			})
+		}
+	}
+
+	return declaration
+}
+
+func inputString(input any) string {
+	value, _ := input.(pulumi.String)
+	return string(value)
+}
+
+func inputStrings(input any) []string {
+	array, _ := input.(pulumi.StringArray)
+	values := []string{}
+	for _, value := range array {
+		values = append(values, inputString(value))
+	}
+
+	return values
+}
+
+func inputBool(input any) bool {
+	value, _ := input.(pulumi.Bool)
+	return bool(value)
+}
+
+func inputInt(input any) int {
+	value, _ := input.(pulumi.Int)
+	return int(value)
+}
+
+func inputIntPtr(input any) *int {
+	value, ok := input.(pulumi.Int)
+	if !ok {
+		return nil
+	}
+	result := int(value)
+
+	return &result
+}
+
+// repositorySetting is a field of github.RepositoryArgs that is compared with the existing repository.
+type repositorySetting struct {
+	Field    string
+	Standard any
+	Current  any
+	// AdminOnly settings are only returned to tokens with admin access, and are empty otherwise.
+	AdminOnly bool
+}
+
+// repositorySettings compares the settings of an existing repository with the standard ones.
+// Settings that the standard configuration doesn't set are compared with the provider's defaults.
+func repositorySettings(current githubRepository) []repositorySetting {
+	standard := standardRepositoryArgs(current.Name, nil)
+	orDefault := func(input any, providerDefault any) any {
+		switch value := input.(type) {
+		case pulumi.Bool:
+			return bool(value)
+		case pulumi.String:
+			return string(value)
+		default:
+			return providerDefault
+		}
+	}
+
+	return []repositorySetting{
+		{Field: "Visibility", Standard: orDefault(standard.Visibility, "public"), Current: current.Visibility},
+		{Field: "HasIssues", Standard: orDefault(standard.HasIssues, true), Current: current.HasIssues},
+		{Field: "HasProjects", Standard: orDefault(standard.HasProjects, true), Current: current.HasProjects},
+		{Field: "HasWiki", Standard: orDefault(standard.HasWiki, true), Current: current.HasWiki},
+		{Field: "HasDiscussions", Standard: orDefault(standard.HasDiscussions, false), Current: current.HasDiscussions},
+		{Field: "HomepageUrl", Standard: orDefault(standard.HomepageUrl, ""), Current: current.Homepage},
+		{Field: "IsTemplate", Standard: orDefault(standard.IsTemplate, false), Current: current.IsTemplate},
+		{Field: "AllowAutoMerge", Standard: orDefault(standard.AllowAutoMerge, false), Current: current.AllowAutoMerge},
+		{Field: "DeleteBranchOnMerge", Standard: orDefault(standard.DeleteBranchOnMerge, false), Current: current.DeleteBranchOnMerge},
+		{Field: "AllowUpdateBranch", Standard: orDefault(standard.AllowUpdateBranch, false), Current: current.AllowUpdateBranch},
+		{Field: "AllowSquashMerge", Standard: orDefault(standard.AllowSquashMerge, true), Current: current.AllowSquashMerge},
+		{Field: "AllowRebaseMerge", Standard: orDefault(standard.AllowRebaseMerge, true), Current: current.AllowRebaseMerge},
+		{Field: "AllowMergeCommit", Standard: orDefault(standard.AllowMergeCommit, true), Current: current.AllowMergeCommit},
+		{Field: "SquashMergeCommitTitle", Standard: orDefault(standard.SquashMergeCommitTitle, "COMMIT_OR_PR_TITLE"), Current: current.SquashMergeCommitTitle, AdminOnly: true},
+		{Field: "SquashMergeCommitMessage", Standard: orDefault(standard.SquashMergeCommitMessage, "COMMIT_MESSAGES"), Current: current.SquashMergeCommitMessage, AdminOnly: true},
+		{Field: "MergeCommitTitle", Standard: orDefault(standard.MergeCommitTitle, "MERGE_MESSAGE"), Current: current.MergeCommitTitle, AdminOnly: true},
+		{Field: "MergeCommitMessage", Standard: orDefault(standard.MergeCommitMessage, "PR_TITLE"), Current: current.MergeCommitMessage, AdminOnly: true},
+		{Field: "WebCommitSignoffRequired", Standard: orDefault(standard.WebCommitSignoffRequired, false), Current: current.WebCommitSignoffRequired},
+	}
+}
+
+func (setting repositorySetting) differs() bool {
+	// An admin-only setting that wasn't returned is left alone, rather than reported as empty.
+	if setting.AdminOnly && setting.Current == "" {
+		return false
+	}
+
+	return setting.Current != setting.Standard
+}
+
+// describeSetting formats the value of a setting for the list of differences.
+func describeSetting(value any) string {
+	if value == "" {
+		return "empty"
+	}
+
+	return fmt.Sprint(value)
+}
+
+func renderInput(value any) string {
+	switch value := value.(type) {
+	case bool:
+		return fmt.Sprintf("pulumi.Bool(%t)", value)
+	case int:
+		return fmt.Sprintf("pulumi.Int(%d)", value)
+	default:
+		return fmt.Sprintf("pulumi.String(%q)", value)
+	}
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// repositoryVariable is the name main.go uses for a repository, for example holochainSerialization
+// for holochain-serialization.
+func repositoryVariable(name string) string {
+	words := nonIdentifier.Split(name, -1)
+	var variable strings.Builder
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		if variable.Len() == 0 {
+			variable.WriteString(strings.ToLower(word[:1]) + word[1:])
+		} else {
+			variable.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	result := variable.String()
+	if result == "" || (result[0] >= '0' && result[0] <= '9') {
+		result = "repository" + result
+	}
+	if token.IsKeyword(result) {
+		result += "Repository"
+	}
+
+	return result
+}
+
+// resourceSuffix turns a ruleset name into the suffix of a resource name.
+func resourceSuffix(name string) string {
+	return strings.Trim(strings.ToLower(nonIdentifier.ReplaceAllString(name, "-")), "-")
+}
+
+// renderImportScaffold renders the declaration for importing a repository without changes, followed
+// by a comment that lists how the repository differs from the standard configuration. The settings
+// that differ are overridden with import shims dated importedOn.
+func renderImportScaffold(imported importedRepository, importedOn string) (string, error) {
+	name := imported.Settings.Name
+	variable := repositoryVariable(name)
+	var code strings.Builder
+
+	description := "nil"
+	if imported.Settings.Description != "" {
+		fmt.Fprintf(&code, "description = %q\n", imported.Settings.Description)
+		description = "&description"
+	}
+	var shims []string
+	for _, setting := range repositorySettings(imported.Settings) {
+		if setting.differs() {
+			shims = append(shims, fmt.Sprintf("ImportShim(%q, %s, %q),\n", setting.Field, renderInput(setting.Current), importedOn))
+		}
+	}
+	if len(imported.Settings.Topics) > 0 {
+		shims = append(shims, fmt.Sprintf("ImportShim(\"Topics\", %s, %q),\n", renderStringArray(imported.Settings.Topics), importedOn))
+	}
+	if len(shims) == 0 {
+		fmt.Fprintf(&code, "%sRepositoryArgs := StandardRepositoryArgs(ctx, %q, %s)\n", variable, name, description)
+	} else {
+		fmt.Fprintf(&code, "%sRepositoryArgs := StandardRepositoryArgs(ctx, %q, %s,\n%s)\n", variable, name, description, strings.Join(shims, ""))
+	}
+	fmt.Fprintf(&code, "%s, err := NewHolochainRepository(ctx, %q, &%sRepositoryArgs, pulumi.Import(pulumi.ID(%q)))\n", variable, name, variable, name)
+	code.WriteString("if err != nil {\nreturn err\n}\n")
+
+	if imported.Settings.DefaultBranch == "main" {
+		fmt.Fprintf(&code, "if err = RequireMainAsDefaultBranch(ctx, %q, %s); err != nil {\nreturn err\n}\n", name, variable)
+	}
+
+	if hasStandardTeamAccess(imported.Teams) {
+		fmt.Fprintf(&code, "if err = StandardRepositoryAccess(ctx, %q, %s); err != nil {\nreturn err\n}\n", name, variable)
+	} else {
+		for _, team := range imported.Teams {
+			fmt.Fprintf(&code, "if _, err = github.NewTeamRepository(ctx, %q, &github.TeamRepositoryArgs{\n", fmt.Sprintf("%s-collaborator-%s", name, team.Slug))
+			fmt.Fprintf(&code, "Repository: %s.Name,\nPermission: %s,\nTeamId: %s,\n", variable, renderInput(team.Permission), renderInput(team.Slug))
+			fmt.Fprintf(&code, "}, pulumi.Import(pulumi.ID(%q)), InRepository(ctx, %q)); err != nil {\nreturn err\n}\n", fmt.Sprintf("%s:%s", team.Slug, name), name)
+		}
+	}
+
+	rulesets := map[string]rulesetDeclaration{}
+	for _, ruleset := range imported.Rulesets {
+		declaration, err := ruleset.declaration()
+		if err != nil {
+			return "", err
+		}
+		rulesets[ruleset.Name] = declaration
+		for _, unsupported := range declaration.Unsupported {
+			fmt.Fprintf(&code, "// TODO: add the %s rule of the %q ruleset, which is not generated\n", unsupported, ruleset.Name)
+		}
+		fmt.Fprintf(&code, "if _, err = github.NewRepositoryRuleset(ctx, %q, &", fmt.Sprintf("%s-%s", name, resourceSuffix(ruleset.Name)))
+		renderRuleset(&code, variable, declaration)
+		fmt.Fprintf(&code, ", pulumi.Import(pulumi.ID(%q)), InRepository(ctx, %q)); err != nil {\nreturn err\n}\n", fmt.Sprintf("%s:%d", name, ruleset.ID), name)
+	}
+
+	if pages := imported.Pages; pages != nil {
+		fmt.Fprintf(&code, "if _, err = github.NewRepositoryPages(ctx, %q, &github.RepositoryPagesArgs{\n", name+"-pages")
+		fmt.Fprintf(&code, "Repository: %s.Name,\n", variable)
+		if pages.BuildType == "workflow" {
+			code.WriteString("BuildType: pulumi.String(\"workflow\"),\n")
+		} else if pages.Source != nil {
+			fmt.Fprintf(&code, "Source: github.RepositoryPagesSourceArgs{\nBranch: %s,\nPath: %s,\n},\n", renderInput(pages.Source.Branch), renderInput(pages.Source.Path))
+		}
+		if pages.Cname != "" {
+			fmt.Fprintf(&code, "Cname: %s,\n", renderInput(pages.Cname))
+		}
+		fmt.Fprintf(&code, "}, pulumi.Import(pulumi.ID(%q)), InRepository(ctx, %q)); err != nil {\nreturn err\n}\n", name, name)
+	}
+
+	if sets, labels := importedLabels(imported.Labels); len(sets) > 0 || len(labels) > 0 {
+		fmt.Fprintf(&code, "if err = AddLabels(ctx, %q, %s, RepositoryLabelsConfig{\n", name, variable)
+		if len(sets) > 0 {
+			var identifiers []string
+			for _, set := range sets {
+				identifiers = append(identifiers, labelSetIdentifier(set))
+			}
+			fmt.Fprintf(&code, "Sets: []LabelSet{%s},\n", strings.Join(identifiers, ", "))
+		}
+		if len(labels) > 0 {
+			var quoted []string
+			for _, label := range labels {
+				quoted = append(quoted, strconv.Quote(string(label)))
+			}
+			fmt.Fprintf(&code, "Labels: []RepositoryLabel{%s},\n", strings.Join(quoted, ", "))
+		}
+		code.WriteString("}); err != nil {\nreturn err\n}\n")
+	}
+
+	formatted, err := formatStatements(code.String())
+	if err != nil {
+		return "", err
+	}
+
+	return formatted + "\n" + importDifferences(imported, rulesets), nil
+}
+
+// taxonomyLabelName returns the name in files/labels.yml of an existing label, which differs if
+// the label still uses one of its aliases.
+func taxonomyLabelName(name string) (RepositoryLabel, bool) {
+	if label, ok := labelTaxonomy.label(RepositoryLabel(name)); ok {
+		return label.Name, true
+	}
+	index := slices.IndexFunc(labelTaxonomy.Labels, func(definition LabelDefinition) bool {
+		return slices.Contains(definition.Aliases, name)
+	})
+	if index < 0 {
+		return "", false
+	}
+
+	return labelTaxonomy.Labels[index].Name, true
+}
+
+// importedLabels returns the label sets that an imported repository has every label of, and the
+// other labels it has from files/labels.yml. Labels that are not in files/labels.yml are left out.
+func importedLabels(existing []githubLabel) ([]LabelSet, []RepositoryLabel) {
+	var names []RepositoryLabel
+	for _, label := range existing {
+		if name, ok := taxonomyLabelName(label.Name); ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	var sets []LabelSet
+	covered := map[RepositoryLabel]bool{}
+	for _, set := range slices.Sorted(maps.Keys(labelTaxonomy.Sets)) {
+		labels := labelTaxonomy.Sets[set].Labels
+		if len(labels) == 0 || slices.ContainsFunc(labels, func(label RepositoryLabel) bool { return !slices.Contains(names, label) }) {
+			continue
+		}
+		sets = append(sets, set)
+		for _, label := range labels {
+			covered[label] = true
+		}
+	}
+
+	var labels []RepositoryLabel
+	for _, name := range names {
+		if !covered[name] {
+			labels = append(labels, name)
+		}
+	}
+
+	return sets, labels
+}
+
+// labelSetIdentifier is how a label set is written in main.go.
+func labelSetIdentifier(set LabelSet) string {
+	switch set {
+	case LabelSetGithubDefaults:
+		return "LabelSetGithubDefaults"
+	case LabelSetBackport:
+		return "LabelSetBackport"
+	default:
+		return fmt.Sprintf("LabelSet(%q)", set)
+	}
+}
+
+func renderStringArray(values []string) string {
+	var array strings.Builder
+	array.WriteString("pulumi.StringArray{")
+	for i, value := range values {
+		if i > 0 {
+			array.WriteString(", ")
+		}
+		array.WriteString(renderInput(value))
+	}
+	array.WriteString("}")
+
+	return array.String()
+}
+
+// renderRuleset renders a ruleset in the same layout as DefaultRepositoryRulesetArgs.
+func renderRuleset(code *strings.Builder, variable string, ruleset rulesetDeclaration) {
+	code.WriteString("github.RepositoryRulesetArgs{\n")
+	fmt.Fprintf(code, "Name: %s,\nRepository: %s.Name,\nTarget: %s,\nEnforcement: %s,\n", renderInput(ruleset.Name), variable, renderInput(ruleset.Target), renderInput(ruleset.Enforcement))
+	code.WriteString("Conditions: &github.RepositoryRulesetConditionsArgs{\nRefName: &github.RepositoryRulesetConditionsRefNameArgs{\nIncludes: pulumi.StringArray{\n")
+	for _, include := range ruleset.Includes {
+		fmt.Fprintf(code, "%s,\n", renderInput(include))
+	}
+	code.WriteString("},\nExcludes: pulumi.StringArray{\n")
+	for _, exclude := range ruleset.Excludes {
+		fmt.Fprintf(code, "%s,\n", renderInput(exclude))
+	}
+	code.WriteString("},\n},\n},\n")
+
+	code.WriteString("Rules: &github.RepositoryRulesetRulesArgs{\n")
+	fmt.Fprintf(code, "Creation: %s,\nUpdate: %s,\nDeletion: %s,\nRequiredLinearHistory: %s,\nRequiredSignatures: %s,\n",
+		renderInput(ruleset.Creation), renderInput(ruleset.Update), renderInput(ruleset.Deletion), renderInput(ruleset.RequiredLinearHistory), renderInput(ruleset.RequiredSignatures))
+	if ruleset.NonFastForward {
+		code.WriteString("NonFastForward: pulumi.Bool(true),\n")
+	}
+	if pullRequest := ruleset.PullRequest; pullRequest != nil {
+		fmt.Fprintf(code, "PullRequest: &github.RepositoryRulesetRulesPullRequestArgs{\nDismissStaleReviewsOnPush: %s,\nRequireCodeOwnerReview: %s,\nRequireLastPushApproval: %s,\nRequiredApprovingReviewCount: %s,\nRequiredReviewThreadResolution: %s,\n},\n",
+			renderInput(pullRequest.DismissStaleReviewsOnPush), renderInput(pullRequest.RequireCodeOwnerReview), renderInput(pullRequest.RequireLastPushApproval),
+			renderInput(pullRequest.RequiredApprovingReviewCount), renderInput(pullRequest.RequiredReviewThreadResolution))
+	}
+	if statusChecks := ruleset.RequiredStatusChecks; statusChecks != nil {
+		code.WriteString("RequiredStatusChecks: &github.RepositoryRulesetRulesRequiredStatusChecksArgs{\nRequiredChecks: github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArray{\n")
+		for _, check := range statusChecks.RequiredChecks {
+			fmt.Fprintf(code, "github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs{\nContext: %s,\n", renderInput(check.Context))
+			if check.IntegrationID != nil {
+				fmt.Fprintf(code, "IntegrationId: %s,\n", renderInput(*check.IntegrationID))
+			}
+			code.WriteString("},\n")
+		}
+		code.WriteString("},\n")
+		if statusChecks.DoNotEnforceOnCreate {
+			code.WriteString("DoNotEnforceOnCreate: pulumi.Bool(true),\n")
+		}
+		fmt.Fprintf(code, "StrictRequiredStatusChecksPolicy: %s,\n},\n", renderInput(statusChecks.StrictRequiredStatusChecksPolicy))
+	}
+	code.WriteString("},\n")
+
+	if len(ruleset.BypassActors) > 0 {
+		code.WriteString("BypassActors: github.RepositoryRulesetBypassActorArray{\n")
+		for _, actor := range ruleset.BypassActors {
+			code.WriteString("&github.RepositoryRulesetBypassActorArgs{\n")
+			if actor.ActorID != nil {
+				fmt.Fprintf(code, "ActorId: %s,\n", renderInput(*actor.ActorID))
+			}
+			fmt.Fprintf(code, "ActorType: %s,\nBypassMode: %s,\n},\n", renderInput(actor.ActorType), renderInput(actor.BypassMode))
+		}
+		code.WriteString("},\n")
+	}
+	code.WriteString("}")
+}
+
+// formatStatements formats statements as they would be inside main.
+func formatStatements(statements string) (string, error) {
+	formatted, err := format.Source([]byte("package main\n\nfunc main() {\n" + statements + "}\n"))
+	if err != nil {
+		return "", fmt.Errorf("formatting the generated declaration: %w\n%s", err, statements)
+	}
+
+	body := strings.TrimSuffix(strings.SplitN(string(formatted), "func main() {\n", 2)[1], "}\n")
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		lines = append(lines, strings.TrimPrefix(line, "\t"))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func hasStandardTeamAccess(teams []githubTeamAccess) bool {
+	if len(teams) != len(standardTeamAccess) {
+		return false
+	}
+	for _, access := range standardTeamAccess {
+		if !slices.Contains(teams, githubTeamAccess{Slug: access.Team, Permission: access.Permission}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// standardRuleset is a ruleset that every repository should have.
+type standardRuleset struct {
+	// function is the function that builds the ruleset's arguments.
+	function    string
+	declaration rulesetDeclaration
+}
+
+// standardRulesets are the standard rulesets by name.
+func standardRulesets() map[string]standardRuleset {
+	repository := &github.Repository{}
+	return map[string]standardRuleset{
+		"default": {function: "DefaultRepositoryRulesetArgs", declaration: rulesetDeclarationFromArgs(DefaultRepositoryRulesetArgs(repository, RulesetOptions{}))},
+		"release": {function: "ReleaseRepositoryRulesetArgs", declaration: rulesetDeclarationFromArgs(ReleaseRepositoryRulesetArgs(repository, RulesetOptions{}))},
+	}
+}
+
+// importDifferences lists how an imported repository differs from the standard configuration,
+// as a Go comment. Rulesets are shown as a diff, where `-` is the existing ruleset and `+` is the
+// standard one.
+func importDifferences(imported importedRepository, rulesets map[string]rulesetDeclaration) string {
+	var differences []string
+
+	for _, setting := range repositorySettings(imported.Settings) {
+		if setting.differs() {
+			differences = append(differences, fmt.Sprintf("%s is %s, the standard is %s", setting.Field, describeSetting(setting.Current), describeSetting(setting.Standard)))
+		}
+	}
+	if imported.Settings.Archived {
+		differences = append(differences, "The repository is archived, add it to repositoryLifecycles as LifecycleArchived")
+	}
+	if len(imported.Settings.Topics) > 0 {
+		differences = append(differences, fmt.Sprintf("Topics are %s, the standard is to leave them unmanaged", strings.Join(imported.Settings.Topics, ", ")))
+	}
+	if branch := imported.Settings.DefaultBranch; branch != "main" {
+		differences = append(differences, fmt.Sprintf("The default branch is %s, use MigrateDefaultBranchToMain to rename it to main", branch))
+	}
+
+	if !hasStandardTeamAccess(imported.Teams) {
+		var teams []string
+		for _, team := range imported.Teams {
+			teams = append(teams, fmt.Sprintf("%s (%s)", team.Slug, team.Permission))
+		}
+		var standard []string
+		for _, access := range standardTeamAccess {
+			standard = append(standard, fmt.Sprintf("%s (%s)", access.Team, access.Permission))
+		}
+		differences = append(differences, fmt.Sprintf("Teams are %s, StandardRepositoryAccess gives %s", orDash(strings.Join(teams, ", ")), strings.Join(standard, ", ")))
+	}
+
+	standard := standardRulesets()
+	for _, name := range []string{"default", "release"} {
+		existing, ok := rulesets[name]
+		if !ok {
+			differences = append(differences, fmt.Sprintf("There is no %q ruleset, add one with %s", name, standard[name].function))
+			continue
+		}
+		if diff := lineDiff(renderRulesetForDiff(existing), renderRulesetForDiff(standard[name].declaration)); diff != "" {
+			differences = append(differences, fmt.Sprintf("The %q ruleset differs from %s:\n%s", name, standard[name].function, diff))
+		}
+	}
+	// The rulesets are listed in the order GitHub returned them, so that the comment is stable.
+	for _, ruleset := range imported.Rulesets {
+		if _, ok := standard[ruleset.Name]; !ok {
+			differences = append(differences, fmt.Sprintf("The %q ruleset is not part of the standard configuration", ruleset.Name))
+		}
+	}
+
+	var unknown, renamed []string
+	names := map[RepositoryLabel]bool{}
+	for _, label := range imported.Labels {
+		names[RepositoryLabel(label.Name)] = true
+		name, ok := taxonomyLabelName(label.Name)
+		if !ok {
+			unknown = append(unknown, label.Name)
+		} else if string(name) != label.Name {
+			renamed = append(renamed, fmt.Sprintf("%s to %s", label.Name, name))
+		}
+	}
+	var missing []string
+	for _, label := range labelTaxonomy.Sets[LabelSetGithubDefaults].Labels {
+		if !names[label] {
+			missing = append(missing, string(label))
+		}
+	}
+	if len(missing) > 0 {
+		differences = append(differences, fmt.Sprintf("The %s labels %s are missing, add the set to AddLabels", LabelSetGithubDefaults, strings.Join(missing, ", ")))
+	}
+	if len(renamed) > 0 {
+		differences = append(differences, fmt.Sprintf("AddLabels will rename the labels %s", strings.Join(renamed, ", ")))
+	}
+	if len(unknown) > 0 {
+		differences = append(differences, fmt.Sprintf("The labels %s are not in files/labels.yml, so they are not declared", strings.Join(unknown, ", ")))
+	}
+
+	if imported.Pages != nil {
+		differences = append(differences, "GitHub Pages is enabled, which is declared separately from the standard configuration")
+	}
+
+	var comment strings.Builder
+	if len(differences) == 0 {
+		comment.WriteString("// The repository matches the standard configuration.\n")
+		return comment.String()
+	}
+	comment.WriteString("// Differences from the standard configuration:\n")
+	for _, difference := range differences {
+		for i, line := range strings.Split(difference, "\n") {
+			if i == 0 {
+				fmt.Fprintf(&comment, "// - %s\n", line)
+			} else {
+				fmt.Fprintf(&comment, "//   %s\n", line)
+			}
+		}
+	}
+
+	return comment.String()
+}
+
+func renderRulesetForDiff(ruleset rulesetDeclaration) []string {
+	var code strings.Builder
+	code.WriteString("_ = ")
+	renderRuleset(&code, "repository", ruleset)
+	code.WriteString("\n")
+	formatted, err := formatStatements(code.String())
+	if err != nil {
+		return strings.Split(code.String(), "\n")
+	}
+
+	// Tabs are replaced so that the diff lines up in the comment.
+	return strings.Split(strings.ReplaceAll(strings.TrimSpace(formatted), "\t", "  "), "\n")
+}
+
+// lineDiff returns the lines that differ between before and after, with the lines around them,
+// or an empty string if they are the same.
+func lineDiff(before []string, after []string) string {
+	// lengths[i][j] is the length of the longest common subsequence of before[i:] and after[j:].
+	lengths := make([][]int, len(before)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	changed := false
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			lines = append(lines, "  "+before[i])
+			i++
+			j++
+		case i < len(before) && (j == len(after) || lengths[i+1][j] >= lengths[i][j+1]):
+			lines = append(lines, "- "+before[i])
+			changed = true
+			i++
+		default:
+			lines = append(lines, "+ "+after[j])
+			changed = true
+			j++
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	// Only keep the lines around the changes.
+	var diff []string
+	for index, line := range lines {
+		near := false
+		for offset := -2; offset <= 2; offset++ {
+			if other := index + offset; other >= 0 && other < len(lines) && !strings.HasPrefix(lines[other], "  ") {
+				near = true
+			}
+		}
+		if near {
+			diff = append(diff, line)
+		} else if len(diff) > 0 && diff[len(diff)-1] != "  ..." {
+			diff = append(diff, "  ...")
+		}
+	}
+
+	return strings.Join(diff, "\n")
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestRenderImportScaffold(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "import", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures in testdata/import")
+	}
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var imported importedRepository
+			if err := json.Unmarshal(content, &imported); err != nil {
+				t.Fatal(err)
+			}
+
+			scaffold, err := renderImportScaffold(imported, "2026-10-19")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := strings.TrimSuffix(fixture, ".json") + ".golden"
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(scaffold), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if scaffold != string(expected) {
+				t.Errorf("the scaffold differs from %s, run go test -run TestRenderImportScaffold -update to update it:\n%s", golden, scaffold)
+			}
+		})
+	}
+}
+
+func TestRepositoryVariable(t *testing.T) {
+	tests := map[string]string{
+		"lair":                    "lair",
+		"holochain-serialization": "holochainSerialization",
+		"must_future":             "mustFuture",
+		"hc-github-config":        "hcGithubConfig",
+		"1password":               "repository1password",
+		"go":                      "goRepository",
+		"holochain.github.io":     "holochainGithubIo",
+		"HDK":                     "hDK",
+	}
+	for name, expected := range tests {
+		if variable := repositoryVariable(name); variable != expected {
+			t.Errorf("expected %s for %s, got %s", expected, name, variable)
+		}
+	}
+}
+
+func TestRepositorySettingDiffers(t *testing.T) {
+	tests := []struct {
+		name     string
+		setting  repositorySetting
+		expected bool
+	}{
+		{name: "same", setting: repositorySetting{Field: "HasWiki", Standard: false, Current: false}},
+		{name: "different", setting: repositorySetting{Field: "HasWiki", Standard: false, Current: true}, expected: true},
+		{name: "empty homepage", setting: repositorySetting{Field: "HomepageUrl", Standard: "https://holochain.org", Current: ""}, expected: true},
+		{name: "admin-only setting that wasn't returned", setting: repositorySetting{Field: "MergeCommitTitle", Standard: "MERGE_MESSAGE", Current: "", AdminOnly: true}},
+		{name: "admin-only setting that differs", setting: repositorySetting{Field: "MergeCommitTitle", Standard: "MERGE_MESSAGE", Current: "PR_TITLE", AdminOnly: true}, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if differs := test.setting.differs(); differs != test.expected {
+				t.Errorf("expected differs to be %t, got %t", test.expected, differs)
+			}
+		})
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   []string
+		after    []string
+		expected string
+	}{
+		{name: "same", before: []string{"a", "b"}, after: []string{"a", "b"}},
+		{name: "empty", before: nil, after: nil},
+		{name: "added", before: []string{"a"}, after: []string{"a", "b"}, expected: "  a\n+ b"},
+		{name: "removed", before: []string{"a", "b"}, after: []string{"b"}, expected: "- a\n  b"},
+		{name: "changed", before: []string{"a", "b", "c"}, after: []string{"a", "x", "c"}, expected: "  a\n- b\n+ x\n  c"},
+		{
+			name:     "distant changes",
+			before:   []string{"a", "1", "2", "3", "4", "5", "6", "z"},
+			after:    []string{"b", "1", "2", "3", "4", "5", "6", "y"},
+			expected: "- a\n+ b\n  1\n  2\n  ...\n  5\n  6\n- z\n+ y",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := lineDiff(test.before, test.after); diff != test.expected {
+				t.Errorf("expected\n%s\ngot\n%s", test.expected, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed files/labels.yml
+var labelsYmlContent string
+
+// RepositoryLabel is the name of a label defined in files/labels.yml.
+type RepositoryLabel string
+
+// The ShouldBackport labels are generated from files/release_lines.yml, see ReleaseLine.BackportLabel.
+const (
+	// HraReleaseLabel must match what the holochain_release_integration CLI looks for
+	HraReleaseLabel RepositoryLabel = "hra-release"
+	// SharedFilesLabel marks the PRs that update the shared files from this repository
+	SharedFilesLabel RepositoryLabel = "shared-files"
+)
+
+// LabelSet is the name of a set of labels defined in files/labels.yml.
+type LabelSet string
+
+const (
+	// LabelSetGithubDefaults are the labels that GitHub creates on new repositories.
+	LabelSetGithubDefaults LabelSet = "github-defaults"
+	// LabelSetBackport are the ShouldBackport labels for each supported release line.
+	LabelSetBackport LabelSet = "backport"
+)
+
+// LabelDefinition is a label in files/labels.yml.
+type LabelDefinition struct {
+	Name        RepositoryLabel `yaml:"name"`
+	Description string          `yaml:"description"`
+	Color       string          `yaml:"color"`
+	// Aliases are previous names of the label, which are renamed to Name.
+	Aliases []string `yaml:"aliases"`
+}
+
+// LabelSetDefinition is a set in files/labels.yml.
+type LabelSetDefinition struct {
+	// Pattern that the names of all labels in the set must match.
+	Pattern string            `yaml:"pattern"`
+	Labels  []RepositoryLabel `yaml:"labels"`
+}
+
+// LabelTaxonomy is the content of files/labels.yml.
+type LabelTaxonomy struct {
+	Labels []LabelDefinition               `yaml:"labels"`
+	Sets   map[LabelSet]LabelSetDefinition `yaml:"sets"`
+}
+
+var labelTaxonomy, releaseLines, labelTaxonomyErr = loadLabelTaxonomy()
+
+var labelColor = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// loadLabelTaxonomy parses files/labels.yml and adds the backport labels of the release lines in
+// files/release_lines.yml.
+func loadLabelTaxonomy() (LabelTaxonomy, []ReleaseLine, error) {
+	lines, err := parseReleaseLines(releaseLinesYmlContent)
+	if err != nil {
+		return LabelTaxonomy{}, nil, err
+	}
+	taxonomy, err := parseLabelTaxonomy(labelsYmlContent, lines)
+
+	return taxonomy, lines, err
+}
+
+func parseLabelTaxonomy(content string, lines []ReleaseLine) (LabelTaxonomy, error) {
+	var taxonomy LabelTaxonomy
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&taxonomy); err != nil {
+		return taxonomy, fmt.Errorf("parsing labels.yml: %w", err)
+	}
+
+	if _, ok := taxonomy.Sets[LabelSetBackport]; ok {
+		return taxonomy, fmt.Errorf("invalid labels.yml: the %s set is generated from release_lines.yml", LabelSetBackport)
+	}
+	backport := LabelSetDefinition{Pattern: backportLabelPattern}
+	for _, line := range lines {
+		taxonomy.Labels = append(taxonomy.Labels, line.labelDefinition())
+		if line.Supported() {
+			backport.Labels = append(backport.Labels, line.BackportLabel())
+		}
+	}
+	if taxonomy.Sets == nil {
+		taxonomy.Sets = map[LabelSet]LabelSetDefinition{}
+	}
+	taxonomy.Sets[LabelSetBackport] = backport
+
+	if err := taxonomy.validate(); err != nil {
+		return taxonomy, fmt.Errorf("invalid labels.yml: %w", err)
+	}
+
+	return taxonomy, nil
+}
+
+func (taxonomy LabelTaxonomy) validate() error {
+	// Names are compared case-insensitively, as they are by GitHub.
+	names := map[string]bool{}
+	for _, label := range taxonomy.Labels {
+		if strings.TrimSpace(string(label.Name)) != string(label.Name) || label.Name == "" {
+			return fmt.Errorf("label %q must have a name without leading or trailing spaces", label.Name)
+		}
+		if len(label.Name) > 50 {
+			return fmt.Errorf("label %q must be at most 50 characters", label.Name)
+		}
+		if len(label.Description) > 100 {
+			return fmt.Errorf("the description of label %q must be at most 100 characters", label.Name)
+		}
+		if !labelColor.MatchString(label.Color) {
+			return fmt.Errorf("label %q has color %q, which must be 6 hex digits without a #", label.Name, label.Color)
+		}
+		for _, name := range append([]string{string(label.Name)}, label.Aliases...) {
+			if names[strings.ToLower(name)] {
+				return fmt.Errorf("label %q is defined more than once, as a name or an alias", name)
+			}
+			names[strings.ToLower(name)] = true
+		}
+	}
+
+	for setName, set := range taxonomy.Sets {
+		var pattern *regexp.Regexp
+		if set.Pattern != "" {
+			var err error
+			if pattern, err = regexp.Compile(set.Pattern); err != nil {
+				return fmt.Errorf("set %q has an invalid pattern: %w", setName, err)
+			}
+		}
+		for _, name := range set.Labels {
+			if _, ok := taxonomy.label(name); !ok {
+				return fmt.Errorf("set %q refers to label %q, which is not defined", setName, name)
+			}
+			if pattern != nil && !pattern.MatchString(string(name)) {
+				return fmt.Errorf("label %q in set %q does not match %s", name, setName, set.Pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (taxonomy LabelTaxonomy) label(name RepositoryLabel) (LabelDefinition, bool) {
+	index := slices.IndexFunc(taxonomy.Labels, func(label LabelDefinition) bool {
+		return label.Name == name
+	})
+	if index < 0 {
+		return LabelDefinition{}, false
+	}
+
+	return taxonomy.Labels[index], true
+}
+
+// RepositoryLabelsConfig selects the labels that are managed on a repository.
+type RepositoryLabelsConfig struct {
+	Sets   []LabelSet
+	Labels []RepositoryLabel
+	// Authoritative removes every label on the repository that is not managed here, including labels
+	// that were created by hand. Labels added by other helpers, such as the release labels, are kept.
+	Authoritative bool
+}
+
+// repositoryLabels are the labels declared for a repository, which are created by SyncRepositoryLabels.
+type repositoryLabels struct {
+	name          string
+	repository    *github.Repository
+	labels        []LabelDefinition
+	authoritative bool
+	// removed are labels that must be deleted from the repository if they exist.
+	removed []RepositoryLabel
+}
+
+// labelsFor returns the labels declared for the named repository, or nil if there are none.
+func (state *programState) labelsFor(name string) *repositoryLabels {
+	index := slices.IndexFunc(state.repositoryLabels, func(declared *repositoryLabels) bool {
+		return declared.name == name
+	})
+	if index < 0 {
+		return nil
+	}
+
+	return state.repositoryLabels[index]
+}
+
+// AddLabels manages the labels selected by config on a repository. The labels are created by
+// SyncRepositoryLabels, once all helpers have had a chance to add labels to the repository.
+func AddLabels(ctx *pulumi.Context, name string, repository *github.Repository, config RepositoryLabelsConfig) error {
+	if labelTaxonomyErr != nil {
+		invalid(ctx, "", "%v", labelTaxonomyErr)
+		return nil
+	}
+
+	labels := slices.Clone(config.Labels)
+	for _, setName := range config.Sets {
+		set, ok := labelTaxonomy.Sets[setName]
+		if !ok {
+			invalid(ctx, name, "unknown label set %q", setName)
+			continue
+		}
+		labels = append(labels, set.Labels...)
+	}
+
+	state := stateFor(ctx)
+	declared := state.labelsFor(name)
+	if declared == nil {
+		declared = &repositoryLabels{name: name, repository: repository}
+		state.repositoryLabels = append(state.repositoryLabels, declared)
+	}
+	declared.authoritative = declared.authoritative || config.Authoritative
+
+	for _, labelName := range labels {
+		label, ok := labelTaxonomy.label(labelName)
+		if !ok {
+			invalid(ctx, name, "unknown label %q, add it to files/labels.yml", labelName)
+			continue
+		}
+		if !slices.ContainsFunc(declared.labels, func(existing LabelDefinition) bool { return existing.Name == label.Name }) {
+			declared.labels = append(declared.labels, label)
+		}
+	}
+
+	return nil
+}
+
+// AddRepositoryLabels creates the specified labels on a repository with consistent
+// name and color configuration.
+func AddRepositoryLabels(ctx *pulumi.Context, name string, repository *github.Repository, labels ...RepositoryLabel) error {
+	return AddLabels(ctx, name, repository, RepositoryLabelsConfig{Labels: labels})
+}
+
+// SyncRepositoryLabels creates the labels added to each repository. It must be called after all
+// repositories are declared.
+//
+// Labels are created individually unless the repository is authoritative, in which case a single
+// IssueLabels resource owns every label on the repository. A label that is no longer declared is
+// deleted from the repository.
+func SyncRepositoryLabels(ctx *pulumi.Context) error {
+	for _, declared := range stateFor(ctx).repositoryLabels {
+		conflicting := false
+		for _, removed := range declared.removed {
+			if slices.ContainsFunc(declared.labels, func(label LabelDefinition) bool { return label.Name == removed }) {
+				invalid(ctx, declared.name, "label %q is both added and removed", removed)
+				conflicting = true
+			}
+		}
+		if conflicting {
+			continue
+		}
+		updateLabelsThroughAPI(ctx, declared)
+
+		if declared.authoritative {
+			var labels github.IssueLabelsLabelArray
+			for _, label := range declared.labels {
+				labels = append(labels, github.IssueLabelsLabelArgs{
+					Name:        pulumi.String(string(label.Name)),
+					Description: pulumi.String(label.Description),
+					Color:       pulumi.String(label.Color),
+				})
+			}
+			if _, err := github.NewIssueLabels(ctx, fmt.Sprintf("%s-labels", declared.name), &github.IssueLabelsArgs{
+				Repository: declared.repository.Name,
+				Labels:     labels,
+			}, InRepository(ctx, declared.name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, label := range declared.labels {
+			args := &github.IssueLabelArgs{
+				Repository: declared.repository.Name,
+				Name:       pulumi.String(string(label.Name)),
+				Color:      pulumi.String(label.Color),
+			}
+			if label.Description != "" {
+				args.Description = pulumi.String(label.Description)
+			}
+			// Create a unique resource ID by combining repo name and label name
+			if _, err := github.NewIssueLabel(ctx, fmt.Sprintf("%s-%s-label", declared.name, label.Name), args, InRepository(ctx, declared.name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateLabelsThroughAPI renames labels on GitHub that still use one of their aliases, so that the
+// label keeps its issues and pull requests instead of being replaced, and deletes removed labels
+// from repositories that aren't authoritative. Changes are made through the GitHub API as the
+// program runs, and are skipped during previews. Like the shared files PR set up, this is best
+// effort: failures are logged rather than failing the deployment, and the next deployment tries
+// again.
+func updateLabelsThroughAPI(ctx *pulumi.Context, declared *repositoryLabels) {
+	hasAliases := slices.ContainsFunc(declared.labels, func(label LabelDefinition) bool { return len(label.Aliases) > 0 })
+	hasRemovals := len(declared.removed) > 0 && !declared.authoritative
+	if ctx.DryRun() || (!hasAliases && !hasRemovals) {
+		return
+	}
+
+	api, err := newGithubAPI(ctx)
+	if err != nil {
+		_ = ctx.Log.Warn(fmt.Sprintf("unable to update the labels of %s: %v", declared.name, err), nil)
+		return
+	}
+
+	for _, label := range declared.labels {
+		for _, alias := range label.Aliases {
+			renamed, err := api.renameLabel(declared.name, alias, label)
+			if err != nil {
+				_ = ctx.Log.Warn(fmt.Sprintf("unable to rename label %q to %q on %s: %v", alias, label.Name, declared.name, err), nil)
+				continue
+			}
+			if renamed {
+				_ = ctx.Log.Info(fmt.Sprintf("renamed label %q to %q on %s", alias, label.Name, declared.name), nil)
+			}
+		}
+	}
+
+	if !hasRemovals {
+		return
+	}
+	for _, label := range declared.removed {
+		deleted, err := api.deleteLabel(declared.name, label)
+		if err != nil {
+			_ = ctx.Log.Warn(fmt.Sprintf("unable to delete label %q from %s: %v", label, declared.name, err), nil)
+			continue
+		}
+		if deleted {
+			_ = ctx.Log.Info(fmt.Sprintf("deleted label %q from %s", label, declared.name), nil)
+		}
+	}
+}
+
+// renameLabel renames the label called from to the given label, returning false if there is no
+// label called from on the repository.
+func (api *githubAPI) renameLabel(repository string, from string, to LabelDefinition) (bool, error) {
+	path := fmt.Sprintf("/repos/%s/%s/labels/%s", githubOrganization, repository, url.PathEscape(from))
+	if err := api.do(http.MethodGet, path, nil, nil); err != nil {
+		if errors.Is(err, errGithubNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, api.do(http.MethodPatch, path, map[string]any{
+		"new_name":    to.Name,
+		"description": to.Description,
+		"color":       to.Color,
+	}, nil)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseLabelTaxonomy(t *testing.T) {
+	lines := []ReleaseLine{
+		{Version: "0.5", Status: ReleaseLineEOL},
+		{Version: "0.6", Status: ReleaseLineSecurityOnly},
+		{Version: "0.7", Status: ReleaseLineActive, LabelAliases: []string{"ShouldBackport07"}},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name: "valid",
+			content: `labels:
+  - name: bug
+    description: Something isn't working
+    color: d73a4a
+  - name: hra-release
+    color: 0E8A16
+    aliases: [release]
+sets:
+  github-defaults:
+    labels: [bug]
+`,
+		},
+		{
+			name:    "unknown field",
+			content: "labels:\n  - name: bug\n    colour: d73a4a\n",
+			err:     "field colour not found",
+		},
+		{
+			name:    "color with a hash",
+			content: "labels:\n  - name: bug\n    color: \"#d73a4a\"\n",
+			err:     `label "bug" has color "#d73a4a", which must be 6 hex digits without a #`,
+		},
+		{
+			name:    "short color",
+			content: "labels:\n  - name: bug\n    color: d73\n",
+			err:     `label "bug" has color "d73"`,
+		},
+		{
+			name:    "empty name",
+			content: "labels:\n  - name: \"\"\n    color: d73a4a\n",
+			err:     `label "" must have a name without leading or trailing spaces`,
+		},
+		{
+			name:    "padded name",
+			content: "labels:\n  - name: \" bug\"\n    color: d73a4a\n",
+			err:     `label " bug" must have a name without leading or trailing spaces`,
+		},
+		{
+			name:    "long name",
+			content: "labels:\n  - name: " + strings.Repeat("a", 51) + "\n    color: d73a4a\n",
+			err:     "must be at most 50 characters",
+		},
+		{
+			name:    "long description",
+			content: "labels:\n  - name: bug\n    description: " + strings.Repeat("a", 101) + "\n    color: d73a4a\n",
+			err:     `the description of label "bug" must be at most 100 characters`,
+		},
+		{
+			name:    "duplicate name with a different case",
+			content: "labels:\n  - name: bug\n    color: d73a4a\n  - name: Bug\n    color: d73a4a\n",
+			err:     `label "Bug" is defined more than once, as a name or an alias`,
+		},
+		{
+			name:    "alias of another label",
+			content: "labels:\n  - name: bug\n    color: d73a4a\n  - name: defect\n    color: d73a4a\n    aliases: [bug]\n",
+			err:     `label "bug" is defined more than once, as a name or an alias`,
+		},
+		{
+			name:    "alias of a generated label",
+			content: "labels:\n  - name: bug\n    color: d73a4a\n    aliases: [ShouldBackport07]\n",
+			err:     `label "ShouldBackport07" is defined more than once, as a name or an alias`,
+		},
+		{
+			name:    "undefined label in a set",
+			content: "labels:\n  - name: bug\n    color: d73a4a\nsets:\n  github-defaults:\n    labels: [bug, wontfix]\n",
+			err:     `set "github-defaults" refers to label "wontfix", which is not defined`,
+		},
+		{
+			name:    "label that doesn't match the set's pattern",
+			content: "labels:\n  - name: bug\n    color: d73a4a\nsets:\n  triage:\n    pattern: ^triage/\n    labels: [bug]\n",
+			err:     `label "bug" in set "triage" does not match ^triage/`,
+		},
+		{
+			name:    "invalid pattern",
+			content: "labels:\n  - name: bug\n    color: d73a4a\nsets:\n  triage:\n    pattern: \"[\"\n    labels: [bug]\n",
+			err:     `set "triage" has an invalid pattern`,
+		},
+		{
+			name:    "backport set",
+			content: "labels:\n  - name: bug\n    color: d73a4a\nsets:\n  backport:\n    labels: [bug]\n",
+			err:     "the backport set is generated from release_lines.yml",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseLabelTaxonomy(test.content, lines)
+			if test.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestParseLabelTaxonomyBackportLabels(t *testing.T) {
+	taxonomy, err := parseLabelTaxonomy("labels: []\n", []ReleaseLine{
+		{Version: "0.5", Status: ReleaseLineEOL},
+		{Version: "0.6", Status: ReleaseLineSecurityOnly},
+		{Version: "0.7", Status: ReleaseLineActive, LabelAliases: []string{"ShouldBackport07"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every release line has a label, but only the supported lines are in the backport set.
+	var names []RepositoryLabel
+	for _, label := range taxonomy.Labels {
+		names = append(names, label.Name)
+	}
+	if expected := []RepositoryLabel{"ShouldBackport/0.5", "ShouldBackport/0.6", "ShouldBackport/0.7"}; !slices.Equal(names, expected) {
+		t.Errorf("expected labels %v, got %v", expected, names)
+	}
+	if expected := []RepositoryLabel{"ShouldBackport/0.6", "ShouldBackport/0.7"}; !slices.Equal(taxonomy.Sets[LabelSetBackport].Labels, expected) {
+		t.Errorf("expected the backport set to be %v, got %v", expected, taxonomy.Sets[LabelSetBackport].Labels)
+	}
+	securityOnly, _ := taxonomy.label("ShouldBackport/0.6")
+	if securityOnly.Color != "D93F0B" {
+		t.Errorf("expected the security-only label to be orange, got %s", securityOnly.Color)
+	}
+}
+
+func TestLabelsYml(t *testing.T) {
+	if labelTaxonomyErr != nil {
+		t.Fatal(labelTaxonomyErr)
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// RepositoryLifecycle is the stage of a repository's life.
+type RepositoryLifecycle string
+
+const (
+	// LifecycleActive repositories are developed and released as normal.
+	LifecycleActive RepositoryLifecycle = "active"
+	// LifecycleMaintenance repositories only receive fixes.
+	LifecycleMaintenance RepositoryLifecycle = "maintenance"
+	// LifecycleDeprecated repositories should no longer be used, but are not archived yet.
+	LifecycleDeprecated RepositoryLifecycle = "deprecated"
+	// LifecycleArchived repositories are read-only. Their secrets, write access, outside
+	// collaborators and shared files are removed before the repository is archived.
+	LifecycleArchived RepositoryLifecycle = "archived"
+)
+
+// RepositoryLifecycleConfig is the lifecycle of a repository.
+type RepositoryLifecycleConfig struct {
+	State RepositoryLifecycle
+	// Replacement is the repository or URL to use instead of a deprecated or archived repository.
+	Replacement string
+	// DeprecationBanner opens a PR that adds a banner to the top of README.md. An archived repository
+	// is only archived once the PR has been merged or closed.
+	DeprecationBanner bool
+}
+
+// repositoryLifecycles are the repositories that are not active. Retiring a repository is done by
+// adding it here, for example:
+//
+//	"must_future": {State: LifecycleArchived, Replacement: "holochain/example", DeprecationBanner: true},
+//
+// devhub-gui and app-store-gui are not declared in this program, so they have to be imported with
+// scaffold-import before they can be retired here.
+var repositoryLifecycles = map[string]RepositoryLifecycleConfig{}
+
+// lifecycleOf returns the lifecycle of the named repository.
+func lifecycleOf(name string) RepositoryLifecycleConfig {
+	lifecycle, ok := repositoryLifecycles[name]
+	if !ok || lifecycle.State == "" {
+		lifecycle.State = LifecycleActive
+	}
+
+	return lifecycle
+}
+
+// isRetired reports whether the repository is archived, or being archived, so that secrets and
+// write access should no longer be granted to it.
+func isRetired(name string) bool {
+	return lifecycleOf(name).State == LifecycleArchived
+}
+
+// lifecycleTopic is added to the topics of repositories that are not active. The topic also marks
+// an archived repository as ready to be archived, see applyRepositoryLifecycle.
+func (lifecycle RepositoryLifecycleConfig) lifecycleTopic() string {
+	if lifecycle.State == LifecycleActive {
+		return ""
+	}
+
+	return string(lifecycle.State)
+}
+
+// applyRepositoryLifecycle updates the description and topics of a repository that is not active.
+// The lifecycle topic is added to the repository's topics: those set with an override, or those it
+// already has on GitHub if the program doesn't set any. If the repository can't be read from GitHub,
+// its topics are left as they are.
+//
+// Archived repositories are archived in two deployments, because GitHub rejects changes to a
+// repository once it is archived. The first deployment removes the secrets, write access and
+// shared files and adds the `archived` topic, and the next deployment archives the repository
+// once it finds that topic on GitHub, and the deprecation banner has landed if one was asked for.
+func applyRepositoryLifecycle(ctx *pulumi.Context, name string, args *github.RepositoryArgs, description *string) {
+	lifecycle := lifecycleOf(name)
+	if lifecycle.State == LifecycleActive {
+		return
+	}
+
+	if prefix := lifecycle.descriptionPrefix(); prefix != "" {
+		text := strings.TrimSuffix(prefix, " ")
+		if description != nil {
+			text = prefix + *description
+		}
+		args.Description = pulumi.String(text)
+	}
+
+	existing, err := github.LookupRepository(ctx, &github.LookupRepositoryArgs{Name: &name})
+	if err != nil {
+		_ = ctx.Log.Warn(fmt.Sprintf("unable to read %s from GitHub, its topics are left unchanged and it is not archived: %v", name, err), nil)
+		return
+	}
+	stateFor(ctx).retiringRepositories[name] = existing
+
+	topic := lifecycle.lifecycleTopic()
+	if args.Topics == nil {
+		args.Topics = pulumi.ToStringArray(withTopic(existing.Topics, topic))
+	} else {
+		args.Topics = args.Topics.ToStringArrayOutput().ApplyT(func(topics []string) []string {
+			return withTopic(topics, topic)
+		}).(pulumi.StringArrayOutput)
+	}
+
+	if lifecycle.State == LifecycleArchived {
+		args.Archived = pulumi.Bool(readyToArchive(ctx, name, lifecycle, existing))
+	}
+}
+
+// withTopic returns the topics with the given topic added, if they don't already include it.
+func withTopic(topics []string, topic string) []string {
+	if slices.Contains(topics, topic) {
+		return topics
+	}
+
+	return append(slices.Clone(topics), topic)
+}
+
+func (lifecycle RepositoryLifecycleConfig) descriptionPrefix() string {
+	switch lifecycle.State {
+	case LifecycleDeprecated:
+		return "[DEPRECATED] "
+	case LifecycleArchived:
+		return "[ARCHIVED] "
+	default:
+		return ""
+	}
+}
+
+// readyToArchive reports whether a previous deployment has prepared the repository to be archived,
+// or it is already archived. A repository that asked for a deprecation banner is only archived once
+// the banner PR has been merged or closed, because the PR can't be merged after that.
+func readyToArchive(ctx *pulumi.Context, name string, lifecycle RepositoryLifecycleConfig, existing *github.LookupRepositoryResult) bool {
+	if existing.Archived {
+		return true
+	}
+	if !slices.Contains(existing.Topics, string(LifecycleArchived)) {
+		_ = ctx.Log.Info(fmt.Sprintf("%s will be archived by the next deployment, once its secrets and access have been removed", name), nil)
+		return false
+	}
+	if !lifecycle.DeprecationBanner {
+		return true
+	}
+
+	api, err := newGithubAPI(ctx)
+	if err != nil {
+		_ = ctx.Log.Warn(fmt.Sprintf("unable to check the deprecation banner of %s, it is not archived: %v", name, err), nil)
+		return false
+	}
+	landed, err := api.deprecationBannerLanded(name, existing.DefaultBranch)
+	if err != nil {
+		_ = ctx.Log.Warn(fmt.Sprintf("unable to check the deprecation banner of %s, it is not archived: %v", name, err), nil)
+		return false
+	}
+	if !landed {
+		_ = ctx.Log.Info(fmt.Sprintf("%s will be archived once its deprecation banner PR is merged or closed", name), nil)
+	}
+
+	return landed
+}
+
+// deprecationBannerMarker is included in the banner so that it is only added once.
+const deprecationBannerMarker = "<!-- deprecation-banner -->"
+
+// deprecationBannerBranch is the branch that the deprecation banner is committed to.
+const deprecationBannerBranch = "chore/deprecation-banner"
+
+// deprecationBannerLanded reports whether the README on the default branch has the deprecation
+// banner, or the banner PR was closed without being merged.
+func (api *githubAPI) deprecationBannerLanded(repository string, defaultBranch string) (bool, error) {
+	readme, err := api.fileContent(repository, "README.md", defaultBranch)
+	if err != nil && !errors.Is(err, errGithubNotFound) {
+		return false, err
+	}
+	if strings.Contains(readme, deprecationBannerMarker) {
+		return true, nil
+	}
+
+	closed, err := api.closedPullRequests(repository, deprecationBannerBranch)
+	if err != nil {
+		return false, err
+	}
+
+	return len(closed) > 0, nil
+}
+
+// addDeprecationBanner opens a PR that adds a deprecation banner to the top of README.md, if the
+// repository is deprecated or archived, asked for a banner, and doesn't have one yet. It is called
+// by NewHolochainRepository. The resources are retained on delete, because they are no longer
+// declared once the PR is merged.
+func addDeprecationBanner(ctx *pulumi.Context, name string, repository *github.Repository) error {
+	lifecycle := lifecycleOf(name)
+	if !lifecycle.DeprecationBanner || (lifecycle.State != LifecycleDeprecated && lifecycle.State != LifecycleArchived) {
+		return nil
+	}
+	// The repository couldn't be read by applyRepositoryLifecycle, or it is archived and can't be changed.
+	existing, ok := stateFor(ctx).retiringRepositories[name]
+	if !ok || existing.Archived {
+		return nil
+	}
+
+	branchName := existing.DefaultBranch
+	if branchName == "" {
+		branchName = "main"
+	}
+	readme, err := github.LookupRepositoryFile(ctx, &github.LookupRepositoryFileArgs{
+		Repository: name,
+		File:       "README.md",
+		Branch:     &branchName,
+	})
+	content := ""
+	if err = lookupError(err); err == nil {
+		content = readme.Content
+	} else if !errors.Is(err, errGithubNotFound) {
+		return fmt.Errorf("reading README.md from %s: %w", name, err)
+	}
+	if strings.Contains(content, deprecationBannerMarker) {
+		return nil
+	}
+
+	content = lifecycle.deprecationBanner() + content
+	contentHash := pulumi.String(fmt.Sprintf("%x", sha256.Sum256([]byte(content))))
+
+	branch, err := github.NewBranch(ctx, fmt.Sprintf("%s-deprecation-banner-branch", name), &github.BranchArgs{
+		Repository:   repository.Name,
+		Branch:       pulumi.String(deprecationBannerBranch),
+		SourceBranch: pulumi.String(branchName),
+	}, pulumi.RetainOnDelete(true), InRepository(ctx, name))
+	if err != nil {
+		return err
+	}
+
+	file, err := github.NewRepositoryFile(ctx, fmt.Sprintf("%s-deprecation-banner-readme", name), &github.RepositoryFileArgs{
+		Repository:        repository.Name,
+		Branch:            branch.Branch,
+		File:              pulumi.String("README.md"),
+		Content:           pulumi.String(content),
+		CommitMessage:     pulumi.String(fmt.Sprintf("docs: mark %s as %s", name, lifecycle.State)),
+		CommitAuthor:      pulumi.String("holochain-release-automation2"),
+		CommitEmail:       pulumi.String("hra@holochain.org"),
+		OverwriteOnCreate: pulumi.Bool(true),
+	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true), InRepository(ctx, name))
+	if err != nil {
+		return err
+	}
+
+	_, err = github.NewRepositoryPullRequest(ctx, fmt.Sprintf("%s-deprecation-banner-pr", name), &github.RepositoryPullRequestArgs{
+		BaseRepository: repository.Name,
+		BaseRef:        pulumi.String(branchName),
+		HeadRef:        branch.Branch,
+		Title:          pulumi.String(fmt.Sprintf("docs: mark %s as %s", name, lifecycle.State)),
+		Body:           pulumi.String(fmt.Sprintf("This PR adds a banner to the README because the repository is %s.", lifecycle.State)),
+	}, pulumi.DependsOn([]pulumi.Resource{file}), pulumi.RetainOnDelete(true), InRepository(ctx, name))
+
+	return err
+}
+
+func (lifecycle RepositoryLifecycleConfig) deprecationBanner() string {
+	var banner strings.Builder
+	banner.WriteString(deprecationBannerMarker + "\n")
+	banner.WriteString("> [!WARNING]\n")
+	if lifecycle.State == LifecycleArchived {
+		banner.WriteString("> This repository is archived and is no longer maintained.")
+	} else {
+		banner.WriteString("> This repository is deprecated and will be archived.")
+	}
+	if lifecycle.Replacement != "" {
+		fmt.Fprintf(&banner, " Use %s instead.", lifecycle.Replacement)
+	}
+	banner.WriteString("\n\n")
+
+	return banner.String()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestWithTopic(t *testing.T) {
+	tests := []struct {
+		name     string
+		topics   []string
+		expected []string
+	}{
+		{name: "no topics", expected: []string{"deprecated"}},
+		{name: "other topics", topics: []string{"holochain", "python"}, expected: []string{"holochain", "python", "deprecated"}},
+		{name: "already added", topics: []string{"deprecated", "holochain"}, expected: []string{"deprecated", "holochain"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			topics := slices.Clone(test.topics)
+			if actual := withTopic(topics, "deprecated"); !slices.Equal(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+			if !slices.Equal(topics, test.topics) {
+				t.Errorf("the topics were modified: %v", topics)
+			}
+		})
+	}
+}
+
+func TestDeprecationBannerLanded(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]string
+		landed    bool
+	}{
+		{
+			name: "banner merged",
+			responses: map[string]string{
+				"/repos/holochain/lair/contents/README.md": `{"path": "README.md", "content": "PCEtLSBkZXByZWNhdGlvbi1iYW5uZXIgLS0+CiMgbGFpcgo="}`,
+			},
+			landed: true,
+		},
+		{
+			name: "banner PR open",
+			responses: map[string]string{
+				"/repos/holochain/lair/contents/README.md": `{"path": "README.md", "content": "IyBsYWlyCg=="}`,
+				"/repos/holochain/lair/pulls":              `[]`,
+			},
+		},
+		{
+			name: "banner PR closed",
+			responses: map[string]string{
+				"/repos/holochain/lair/contents/README.md": `{"path": "README.md", "content": "IyBsYWlyCg=="}`,
+				"/repos/holochain/lair/pulls":              `[{"number": 12, "state": "closed"}]`,
+			},
+			landed: true,
+		},
+		{
+			name: "no README",
+			responses: map[string]string{
+				"/repos/holochain/lair/pulls": `[]`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/repos/holochain/lair/contents/README.md" && r.URL.Query().Get("ref") != "develop" {
+					t.Errorf("README.md read from %q, want the default branch", r.URL.Query().Get("ref"))
+				}
+				if r.URL.Path == "/repos/holochain/lair/pulls" && (r.URL.Query().Get("state") != "closed" || r.URL.Query().Get("head") != "holochain:chore/deprecation-banner") {
+					t.Errorf("unexpected pull request query %s", r.URL.RawQuery)
+				}
+				response, ok := test.responses[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				_, _ = w.Write([]byte(response))
+			}))
+			defer server.Close()
+			api := newGithubAPIWithToken("token")
+			api.baseURL = server.URL
+
+			landed, err := api.deprecationBannerLanded("lair", "develop")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if landed != test.landed {
+				t.Errorf("deprecationBannerLanded() = %t, want %t", landed, test.landed)
+			}
+		})
+	}
+}
@@ -1,37 +1,26 @@
 package main
 
 import (
-	"bytes"
-	"crypto/sha256"
-	_ "embed"
 	"fmt"
-	"html/template"
-	"strings"
+	"os"
 
 	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
-	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
 )
 
-//go:embed files/CONTRIBUTING.md
-var contributingMdContent string
-
-//go:embed files/AI_POLICY.md
-var aiPolicyMdContent string
-
-//go:embed files/CODEOWNERS
-var codeOwnersContent string
-
-//go:embed files/dependabot.yml.tmpl
-var dependabotYmlContent string
-
 func main() {
-	pulumi.Run(func(ctx *pulumi.Context) error {
-		conf := config.New(ctx, "")
+	if len(os.Args) > 1 && os.Args[1] == ScaffoldImportCommand {
+		if err := runScaffoldImport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
+	declare := func(ctx *pulumi.Context) error {
 		description := "Automation for GitHub repository configurations for the Holochain organization."
-		selfRepositoryArgs := StandardRepositoryArgs("hc-github-config", &description)
-		self, err := github.NewRepository(ctx, "hc-github-config", &selfRepositoryArgs, pulumi.Import(pulumi.ID("hc-github-config")))
+		selfRepositoryArgs := StandardRepositoryArgs(ctx, "hc-github-config", &description)
+		self, err := NewHolochainRepository(ctx, "hc-github-config", &selfRepositoryArgs, pulumi.Import(pulumi.ID("hc-github-config")))
 		if err != nil {
 			return err
 		}
@@ -41,8 +30,8 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-github-config", self); err != nil {
 			return err
 		}
-		selfDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(self, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-github-config-default", &selfDefaultRepositoryRulesetArgs); err != nil {
+		selfDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(self, NewRulesetOptions(ctx, "hc-github-config"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-github-config-default", &selfDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-github-config")); err != nil {
 			return err
 		}
 		if _, err := github.NewRepositoryRuleset(ctx, "hc-github-config", &github.RepositoryRulesetArgs{
@@ -67,7 +56,7 @@ func main() {
 				RequiredStatusChecks: &github.RepositoryRulesetRulesRequiredStatusChecksArgs{
 					RequiredChecks: github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArray{
 						github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs{
-							Context: pulumi.String("ci_pass"),
+							Context: pulumi.String(CiPassCheck),
 						},
 					},
 					StrictRequiredStatusChecksPolicy: pulumi.Bool(true),
@@ -80,27 +69,37 @@ func main() {
 					BypassMode: pulumi.String("always"),
 				},
 			},
-		}); err != nil {
+		}, InRepository(ctx, "hc-github-config")); err != nil {
 			return err
 		}
-		if err = AddGithubAdminTokenSecret(ctx, conf, "hc-github-config"); err != nil {
+		if err = AddGithubToken(ctx, "hc-github-config", GithubTokenConfig{Actions: GithubTokenAdmin, Dependabot: GithubTokenAdmin}); err != nil {
 			return err
 		}
-		if err = AddGithubAdminTokenSecretForDependabot(ctx, conf, "hc-github-config"); err != nil {
+		if err = AddPulumiAccessTokenSecret(ctx, "hc-github-config"); err != nil {
 			return err
 		}
-		if err = AddPulumiAccessTokenSecret(ctx, conf, "hc-github-config"); err != nil {
+		// Pinned, so that a Pulumi Cloud trust policy for the deploy workflow keeps matching if the
+		// organization's claims change.
+		if err = AddOidcSubjectClaims(ctx, "hc-github-config", self, "repo", "context"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "hc-github-config", self); err != nil {
+		if err = AddSharedFiles(ctx, "hc-github-config", self, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: description,
+				Ecosystems:  []Ecosystem{EcosystemGo, EcosystemNix},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			AutoMerge:  true,
+			Workflows:  []Workflow{WorkflowCiPass, WorkflowPullRequestTitle},
+		}); err != nil {
 			return err
 		}
 
 		//
 		// holochain-wasmer
 		//
-		holochainWasmerRepositoryArgs := StandardRepositoryArgs("holochain-wasmer", nil)
-		holochainWasmer, err := github.NewRepository(ctx, "holochain-wasmer", &holochainWasmerRepositoryArgs, pulumi.Import(pulumi.ID("holochain-wasmer")))
+		holochainWasmerRepositoryArgs := StandardRepositoryArgs(ctx, "holochain-wasmer", nil)
+		holochainWasmer, err := NewHolochainRepository(ctx, "holochain-wasmer", &holochainWasmerRepositoryArgs, pulumi.Import(pulumi.ID("holochain-wasmer")))
 		if err != nil {
 			return err
 		}
@@ -110,27 +109,29 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "holochain-wasmer", holochainWasmer); err != nil {
 			return err
 		}
-		holochainWasmerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(holochainWasmer, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "holochain-wasmer-default", &holochainWasmerDefaultRepositoryRulesetArgs); err != nil {
+		holochainWasmerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(holochainWasmer, NewRulesetOptions(ctx, "holochain-wasmer"))
+		if _, err = github.NewRepositoryRuleset(ctx, "holochain-wasmer-default", &holochainWasmerDefaultRepositoryRulesetArgs, InRepository(ctx, "holochain-wasmer")); err != nil {
 			return err
 		}
-		holochainWasmerReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(holochainWasmer, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "holochain-wasmer-release", &holochainWasmerReleaseRepositoryRulesetArgs); err != nil {
+		holochainWasmerReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(holochainWasmer, NewRulesetOptions(ctx, "holochain-wasmer"))
+		if _, err = github.NewRepositoryRuleset(ctx, "holochain-wasmer-release", &holochainWasmerReleaseRepositoryRulesetArgs, InRepository(ctx, "holochain-wasmer")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "holochain-wasmer", holochainWasmer); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "holochain-wasmer", holochainWasmer); err != nil {
 			return err
 		}
-		if err = AddCachixAuthTokenSecret(ctx, conf, "holochain-wasmer"); err != nil {
+		if err = AddCachixAuthTokenSecret(ctx, "holochain-wasmer"); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "holochain-wasmer", holochainWasmer); err != nil {
-			return err
-		}
-		if err = AddCodeOwners(ctx, "holochain-wasmer", holochainWasmer); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "holochain-wasmer", holochainWasmer, DependabotConfig{EnableRust: true}); err != nil {
+		if err = AddSharedFiles(ctx, "holochain-wasmer", holochainWasmer, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Ecosystems:    []Ecosystem{EcosystemRust},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot:        &DependabotConfig{EnableRust: true},
+		}); err != nil {
 			return err
 		}
 		if err = AddOutsideCollaborator(ctx, "holochain-wasmer", holochainWasmer, "synchwire"); err != nil {
@@ -141,8 +142,8 @@ func main() {
 		// wind tunnel
 		//
 		description = "Performance testing for Holochain"
-		windTunnelRepositoryArgs := StandardRepositoryArgs("wind-tunnel", &description)
-		windTunnel, err := github.NewRepository(ctx, "wind-tunnel", &windTunnelRepositoryArgs, pulumi.Import(pulumi.ID("wind-tunnel")))
+		windTunnelRepositoryArgs := StandardRepositoryArgs(ctx, "wind-tunnel", &description)
+		windTunnel, err := NewHolochainRepository(ctx, "wind-tunnel", &windTunnelRepositoryArgs, pulumi.Import(pulumi.ID("wind-tunnel")))
 
 		if err != nil {
 			return err
@@ -153,12 +154,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "wind-tunnel", windTunnel); err != nil {
 			return err
 		}
-		windTunnelDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(windTunnel, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-default", &windTunnelDefaultRepositoryRulesetArgs); err != nil {
+		windTunnelDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(windTunnel, NewRulesetOptions(ctx, "wind-tunnel"))
+		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-default", &windTunnelDefaultRepositoryRulesetArgs, InRepository(ctx, "wind-tunnel")); err != nil {
 			return err
 		}
-		windTunnelReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(windTunnel, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-release", &windTunnelReleaseRepositoryRulesetArgs); err != nil {
+		windTunnelReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(windTunnel, NewRulesetOptions(ctx, "wind-tunnel"))
+		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-release", &windTunnelReleaseRepositoryRulesetArgs, InRepository(ctx, "wind-tunnel")); err != nil {
 			return err
 		}
 		_, err = github.NewRepositoryPages(ctx, "wind-tunnel-pages", &github.RepositoryPagesArgs{
@@ -167,39 +168,54 @@ func main() {
 				Branch: pulumi.String("gh-pages"),
 				Path:   pulumi.String("/"),
 			},
-		}, pulumi.Import(pulumi.ID("wind-tunnel")))
+		}, pulumi.Import(pulumi.ID("wind-tunnel")), InRepository(ctx, "wind-tunnel"))
 		if err != nil {
 			return err
 		}
-		windTunnelConf := config.New(ctx, "wind-tunnel")
-		if err = AddNomadAccessTokenSecret(ctx, windTunnelConf, "wind-tunnel"); err != nil {
+		if err = AddNomadAccessTokenSecret(ctx, "wind-tunnel"); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "wind-tunnel", windTunnel); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "wind-tunnel", windTunnel); err != nil {
 			return err
 		}
-		if err = AddHetznerHolochainInfraBucketsSecret(ctx, conf, "wind-tunnel"); err != nil {
+		if err = AddHetznerHolochainInfraBucketsSecret(ctx, "wind-tunnel"); err != nil {
 			return err
 		}
-		if err = AddCachixAuthTokenSecret(ctx, conf, "wind-tunnel"); err != nil {
+		if err = AddCachixAuthTokenSecret(ctx, "wind-tunnel"); err != nil {
 			return err
 		}
-		if err = AddClaudeCodeOauthTokenSecret(ctx, conf, "wind-tunnel"); err != nil {
+		if err = AddClaudeCodeOauthTokenSecret(ctx, "wind-tunnel"); err != nil {
 			return err
 		}
-		if err = AddThreefoldTfChainWalletMnemonic(ctx, conf, "wind-tunnel"); err != nil {
+		if err = AddThreefoldTfChainWalletMnemonic(ctx, "wind-tunnel"); err != nil {
 			return err
 		}
-		if err = AddHolochainNotifierMattermostBotPersonalAccessToken(ctx, conf, "wind-tunnel"); err != nil {
+		if err = AddHolochainNotifierMattermostBotPersonalAccessToken(ctx, "wind-tunnel"); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "wind-tunnel", windTunnel); err != nil {
-			return err
-		}
-		if err = AddCodeOwners(ctx, "wind-tunnel", windTunnel); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "wind-tunnel", windTunnel, DependabotConfig{EnableRust: true, EnableNix: true, EnableGo: true}); err != nil {
+		AddRunnerGroup(ctx, "wind-tunnel", RunnerGroupPerformance)
+		if err = AddSharedFiles(ctx, "wind-tunnel", windTunnel, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   description,
+				Ecosystems:    []Ecosystem{EcosystemRust, EcosystemGo, EcosystemNix},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{
+				EnableRust: true,
+				EnableNix:  true,
+				Updates: []DependabotUpdate{
+					{
+						// Find Go modules in any directory rather than only at the root.
+						Ecosystem:   DependabotGomod,
+						Directories: []string{"/**/*"},
+						Schedule:    DependabotSchedule{Interval: "monthly"},
+						Groups:      []DependabotGroup{{Name: "gomod", Patterns: []string{"*"}}},
+					},
+				},
+			},
+		}); err != nil {
 			return err
 		}
 
@@ -207,8 +223,8 @@ func main() {
 		// Holochain JS client
 		//
 		description = "A JavaScript client for the Holochain Conductor API"
-		jsClientRepositoryArgs := StandardRepositoryArgs("holochain-client-js", &description)
-		jsClient, err := github.NewRepository(ctx, "holochain-client-js", &jsClientRepositoryArgs, pulumi.Import(pulumi.ID("holochain-client-js")))
+		jsClientRepositoryArgs := StandardRepositoryArgs(ctx, "holochain-client-js", &description)
+		jsClient, err := NewHolochainRepository(ctx, "holochain-client-js", &jsClientRepositoryArgs, pulumi.Import(pulumi.ID("holochain-client-js")))
 		if err != nil {
 			return err
 		}
@@ -218,24 +234,29 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "holochain-client-js", jsClient); err != nil {
 			return err
 		}
-		jsClientDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(jsClient, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "holochain-client-js-default", &jsClientDefaultRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		jsClientReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(jsClient, NewRulesetOptions().noLinearHistoryRequired())
-		if _, err = github.NewRepositoryRuleset(ctx, "holochain-client-js-release", &jsClientReleaseRepositoryRulesetArgs); err != nil {
+		jsClientDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(jsClient, NewRulesetOptions(ctx, "holochain-client-js"))
+		if _, err = github.NewRepositoryRuleset(ctx, "holochain-client-js-default", &jsClientDefaultRepositoryRulesetArgs, InRepository(ctx, "holochain-client-js")); err != nil {
 			return err
 		}
-		if err = AddNpmReleaseSupport(ctx, conf, "holochain-client-js", jsClient); err != nil {
+		jsClientReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(jsClient, NewRulesetOptions(ctx, "holochain-client-js").noLinearHistoryRequired())
+		if _, err = github.NewRepositoryRuleset(ctx, "holochain-client-js-release", &jsClientReleaseRepositoryRulesetArgs, InRepository(ctx, "holochain-client-js")); err != nil {
 			return err
 		}
-		if err = AddHolochainBackportLabels(ctx, "holochain-client-js", jsClient); err != nil {
+		if err = AddNpmReleaseSupport(ctx, "holochain-client-js", jsClient); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "holochain-client-js", jsClient); err != nil {
+		if err = AddReleaseLines(ctx, "holochain-client-js", jsClient, ReleaseLinesConfig{}); err != nil {
 			return err
 		}
-		if err = AddDependabotYml(ctx, "holochain-client-js", jsClient, DependabotConfig{EnableNix: true, EnableNpm: true}); err != nil {
+		if err = AddSharedFiles(ctx, "holochain-client-js", jsClient, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   description,
+				Ecosystems:    []Ecosystem{EcosystemNpm, EcosystemNix},
+				ReleaseScheme: ReleaseSchemeNpm,
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{EnableNix: true, EnableNpm: true},
+		}); err != nil {
 			return err
 		}
 
@@ -243,8 +264,8 @@ func main() {
 		// Holochain Rust client
 		//
 		description = "A Rust client for the Holochain Conductor API"
-		rustClientRepositoryArgs := StandardRepositoryArgs("holochain-client-rust", &description)
-		rustClient, err := github.NewRepository(ctx, "holochain-client-rust", &rustClientRepositoryArgs, pulumi.Import(pulumi.ID("holochain-client-rust")))
+		rustClientRepositoryArgs := StandardRepositoryArgs(ctx, "holochain-client-rust", &description)
+		rustClient, err := NewHolochainRepository(ctx, "holochain-client-rust", &rustClientRepositoryArgs, pulumi.Import(pulumi.ID("holochain-client-rust")))
 		if err != nil {
 			return err
 		}
@@ -259,8 +280,8 @@ func main() {
 		// Tryorama
 		//
 		description = "Toolset to manage Holochain conductors and facilitate test scenarios"
-		tryoramaRepositoryArgs := StandardRepositoryArgs("tryorama", &description)
-		tryorama, err := github.NewRepository(ctx, "tryorama", &tryoramaRepositoryArgs, pulumi.Import(pulumi.ID("tryorama")))
+		tryoramaRepositoryArgs := StandardRepositoryArgs(ctx, "tryorama", &description)
+		tryorama, err := NewHolochainRepository(ctx, "tryorama", &tryoramaRepositoryArgs, pulumi.Import(pulumi.ID("tryorama")))
 		if err != nil {
 			return err
 		}
@@ -270,12 +291,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "tryorama", tryorama); err != nil {
 			return err
 		}
-		tryoramaDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(tryorama, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "tryorama-default", &tryoramaDefaultRepositoryRulesetArgs); err != nil {
+		tryoramaDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(tryorama, NewRulesetOptions(ctx, "tryorama"))
+		if _, err = github.NewRepositoryRuleset(ctx, "tryorama-default", &tryoramaDefaultRepositoryRulesetArgs, InRepository(ctx, "tryorama")); err != nil {
 			return err
 		}
-		tryoramaReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(tryorama, NewRulesetOptions().noLinearHistoryRequired())
-		if _, err = github.NewRepositoryRuleset(ctx, "tryorama-release", &tryoramaReleaseRepositoryRulesetArgs); err != nil {
+		tryoramaReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(tryorama, NewRulesetOptions(ctx, "tryorama").noLinearHistoryRequired())
+		if _, err = github.NewRepositoryRuleset(ctx, "tryorama-release", &tryoramaReleaseRepositoryRulesetArgs, InRepository(ctx, "tryorama")); err != nil {
 			return err
 		}
 
@@ -283,8 +304,8 @@ func main() {
 		// Holonix
 		//
 		description = "Holochain app development environment based on Nix."
-		holonixRepositoryArgs := StandardRepositoryArgs("holonix", &description)
-		holonix, err := github.NewRepository(ctx, "holonix", &holonixRepositoryArgs, pulumi.Import(pulumi.ID("holonix")))
+		holonixRepositoryArgs := StandardRepositoryArgs(ctx, "holonix", &description)
+		holonix, err := NewHolochainRepository(ctx, "holonix", &holonixRepositoryArgs, pulumi.Import(pulumi.ID("holonix")))
 		if err != nil {
 			return err
 		}
@@ -294,27 +315,31 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "holonix", holonix); err != nil {
 			return err
 		}
-		holonixDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(holonix, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "holonix-default", &holonixDefaultRepositoryRulesetArgs); err != nil {
+		holonixDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(holonix, NewRulesetOptions(ctx, "holonix"))
+		if _, err = github.NewRepositoryRuleset(ctx, "holonix-default", &holonixDefaultRepositoryRulesetArgs, InRepository(ctx, "holonix")); err != nil {
 			return err
 		}
-		holonixReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(holonix, NewRulesetOptions().noLinearHistoryRequired())
-		if _, err = github.NewRepositoryRuleset(ctx, "holonix-release", &holonixReleaseRepositoryRulesetArgs); err != nil {
+		holonixReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(holonix, NewRulesetOptions(ctx, "holonix").noLinearHistoryRequired())
+		if _, err = github.NewRepositoryRuleset(ctx, "holonix-release", &holonixReleaseRepositoryRulesetArgs, InRepository(ctx, "holonix")); err != nil {
 			return err
 		}
-		if err = AddGithubUserTokenSecret(ctx, conf, "holonix"); err != nil {
+		if err = AddGithubToken(ctx, "holonix", GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 			return err
 		}
-		if err = AddCachixAuthTokenSecret(ctx, conf, "holonix"); err != nil {
+		if err = AddCachixAuthTokenSecret(ctx, "holonix"); err != nil {
 			return err
 		}
-		if err = AddHolochainBackportLabels(ctx, "holonix", holonix); err != nil {
+		if err = AddReleaseLines(ctx, "holonix", holonix, ReleaseLinesConfig{}); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "holonix", holonix); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "holonix", holonix, DependabotConfig{EnableNix: true}); err != nil {
+		if err = AddSharedFiles(ctx, "holonix", holonix, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: description,
+				Ecosystems:  []Ecosystem{EcosystemNix},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{EnableNix: true},
+		}); err != nil {
 			return err
 		}
 
@@ -322,8 +347,8 @@ func main() {
 		// Binaries
 		//
 		description = "Holochain binaries for supported platforms"
-		binariesRepositoryArgs := StandardRepositoryArgs("binaries", &description)
-		binaries, err := github.NewRepository(ctx, "binaries", &binariesRepositoryArgs, pulumi.Import(pulumi.ID("binaries")))
+		binariesRepositoryArgs := StandardRepositoryArgs(ctx, "binaries", &description)
+		binaries, err := NewHolochainRepository(ctx, "binaries", &binariesRepositoryArgs, pulumi.Import(pulumi.ID("binaries")))
 		if err != nil {
 			return err
 		}
@@ -333,21 +358,24 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "binaries", binaries); err != nil {
 			return err
 		}
-		binariesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(binaries, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "binaries-default", &binariesDefaultRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		binariesReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(binaries, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "binaries-release", &binariesReleaseRepositoryRulesetArgs); err != nil {
+		binariesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(binaries, NewRulesetOptions(ctx, "binaries"))
+		if _, err = github.NewRepositoryRuleset(ctx, "binaries-default", &binariesDefaultRepositoryRulesetArgs, InRepository(ctx, "binaries")); err != nil {
 			return err
 		}
-		if err = AddHolochainBackportLabels(ctx, "binaries", binaries); err != nil {
+		binariesReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(binaries, NewRulesetOptions(ctx, "binaries"))
+		if _, err = github.NewRepositoryRuleset(ctx, "binaries-release", &binariesReleaseRepositoryRulesetArgs, InRepository(ctx, "binaries")); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "binaries", binaries); err != nil {
+		if err = AddReleaseLines(ctx, "binaries", binaries, ReleaseLinesConfig{}); err != nil {
 			return err
 		}
-		if err = AddDependabotYml(ctx, "binaries", binaries, DependabotConfig{}); err != nil {
+		if err = AddSharedFiles(ctx, "binaries", binaries, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: description,
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -355,8 +383,8 @@ func main() {
 		// Signal bends decently
 		//
 		description = "Simple websocket-based message relay servers and clients"
-		sbdRepositoryArgs := StandardRepositoryArgs("sbd", &description)
-		sbd, err := github.NewRepository(ctx, "sbd", &sbdRepositoryArgs, pulumi.Import(pulumi.ID("sbd")))
+		sbdRepositoryArgs := StandardRepositoryArgs(ctx, "sbd", &description)
+		sbd, err := NewHolochainRepository(ctx, "sbd", &sbdRepositoryArgs, pulumi.Import(pulumi.ID("sbd")))
 		if err != nil {
 			return err
 		}
@@ -366,18 +394,24 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "sbd", sbd); err != nil {
 			return err
 		}
-		sbdDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(sbd, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "sbd-default", &sbdDefaultRepositoryRulesetArgs); err != nil {
+		sbdDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(sbd, NewRulesetOptions(ctx, "sbd"))
+		if _, err = github.NewRepositoryRuleset(ctx, "sbd-default", &sbdDefaultRepositoryRulesetArgs, InRepository(ctx, "sbd")); err != nil {
 			return err
 		}
-		sbdReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(sbd, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "sbd-release", &sbdReleaseRepositoryRulesetArgs); err != nil {
+		sbdReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(sbd, NewRulesetOptions(ctx, "sbd"))
+		if _, err = github.NewRepositoryRuleset(ctx, "sbd-release", &sbdReleaseRepositoryRulesetArgs, InRepository(ctx, "sbd")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "sbd", sbd); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "sbd", sbd); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "sbd", sbd); err != nil {
+		if err = AddSharedFiles(ctx, "sbd", sbd, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   description,
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+		}); err != nil {
 			return err
 		}
 
@@ -385,8 +419,8 @@ func main() {
 		// Tx5
 		//
 		description = "Holochain WebRTC P2P Communication Ecosystem"
-		tx5RepositoryArgs := StandardRepositoryArgs("tx5", &description)
-		tx5, err := github.NewRepository(ctx, "tx5", &tx5RepositoryArgs, pulumi.Import(pulumi.ID("tx5")))
+		tx5RepositoryArgs := StandardRepositoryArgs(ctx, "tx5", &description)
+		tx5, err := NewHolochainRepository(ctx, "tx5", &tx5RepositoryArgs, pulumi.Import(pulumi.ID("tx5")))
 		if err != nil {
 			return err
 		}
@@ -396,18 +430,24 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "tx5", tx5); err != nil {
 			return err
 		}
-		tx5DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(tx5, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "tx5-default", &tx5DefaultRepositoryRulesetArgs); err != nil {
+		tx5DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(tx5, NewRulesetOptions(ctx, "tx5"))
+		if _, err = github.NewRepositoryRuleset(ctx, "tx5-default", &tx5DefaultRepositoryRulesetArgs, InRepository(ctx, "tx5")); err != nil {
 			return err
 		}
-		tx5ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(tx5, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "tx5-release", &tx5ReleaseRepositoryRulesetArgs); err != nil {
+		tx5ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(tx5, NewRulesetOptions(ctx, "tx5"))
+		if _, err = github.NewRepositoryRuleset(ctx, "tx5-release", &tx5ReleaseRepositoryRulesetArgs, InRepository(ctx, "tx5")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "tx5", tx5); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "tx5", tx5); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "tx5", tx5); err != nil {
+		if err = AddSharedFiles(ctx, "tx5", tx5, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   description,
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+		}); err != nil {
 			return err
 		}
 
@@ -415,8 +455,8 @@ func main() {
 		// Lair Keystore
 		//
 		description = "secret lair private keystore"
-		lairRepositoryArgs := StandardRepositoryArgs("lair", &description)
-		lair, err := github.NewRepository(ctx, "lair", &lairRepositoryArgs, pulumi.Import(pulumi.ID("lair")))
+		lairRepositoryArgs := StandardRepositoryArgs(ctx, "lair", &description)
+		lair, err := NewHolochainRepository(ctx, "lair", &lairRepositoryArgs, pulumi.Import(pulumi.ID("lair")))
 		if err != nil {
 			return err
 		}
@@ -426,24 +466,27 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "lair", lair); err != nil {
 			return err
 		}
-		lairDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(lair, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "lair-default", &lairDefaultRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		lairReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(lair, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "lair-release", &lairReleaseRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "lair", lair); err != nil {
+		lairDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(lair, NewRulesetOptions(ctx, "lair"))
+		if _, err = github.NewRepositoryRuleset(ctx, "lair-default", &lairDefaultRepositoryRulesetArgs, InRepository(ctx, "lair")); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "lair", lair); err != nil {
+		lairReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(lair, NewRulesetOptions(ctx, "lair"))
+		if _, err = github.NewRepositoryRuleset(ctx, "lair-release", &lairReleaseRepositoryRulesetArgs, InRepository(ctx, "lair")); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "lair", lair); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "lair", lair); err != nil {
 			return err
 		}
-		if err = AddDependabotYml(ctx, "lair", lair, DependabotConfig{}); err != nil {
+		if err = AddSharedFiles(ctx, "lair", lair, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   description,
+				Ecosystems:    []Ecosystem{EcosystemRust},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot:        &DependabotConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -451,8 +494,8 @@ func main() {
 		// Holochain CHC Service
 		//
 		description = "A local web server that implements the CHC (Chain Head Coordinator) interface in Rust"
-		hcChcServiceRepositoryArgs := StandardRepositoryArgs("hc-chc-service", &description)
-		hcChcService, err := github.NewRepository(ctx, "hc-chc-service", &hcChcServiceRepositoryArgs, pulumi.Import(pulumi.ID("hc-chc-service")))
+		hcChcServiceRepositoryArgs := StandardRepositoryArgs(ctx, "hc-chc-service", &description)
+		hcChcService, err := NewHolochainRepository(ctx, "hc-chc-service", &hcChcServiceRepositoryArgs, pulumi.Import(pulumi.ID("hc-chc-service")))
 		if err != nil {
 			return err
 		}
@@ -462,15 +505,15 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-chc-service", hcChcService); err != nil {
 			return err
 		}
-		hcChcServiceDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcChcService, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-chc-service-default", &hcChcServiceDefaultRepositoryRulesetArgs); err != nil {
+		hcChcServiceDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcChcService, NewRulesetOptions(ctx, "hc-chc-service"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-chc-service-default", &hcChcServiceDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-chc-service")); err != nil {
 			return err
 		}
-		hcChcServiceReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcChcService, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-chc-service-release", &hcChcServiceReleaseRepositoryRulesetArgs); err != nil {
+		hcChcServiceReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcChcService, NewRulesetOptions(ctx, "hc-chc-service"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-chc-service-release", &hcChcServiceReleaseRepositoryRulesetArgs, InRepository(ctx, "hc-chc-service")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "hc-chc-service", hcChcService); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "hc-chc-service", hcChcService); err != nil {
 			return err
 		}
 
@@ -478,8 +521,8 @@ func main() {
 		// Holochain Serialization
 		//
 		description = "Abstractions to probably serialize and deserialize things properly without forgetting or doubling"
-		holochainSerializationRepositoryArgs := StandardRepositoryArgs("holochain-serialization", &description)
-		holochainSerialization, err := github.NewRepository(ctx, "holochain-serialization", &holochainSerializationRepositoryArgs, pulumi.Import(pulumi.ID("holochain-serialization")))
+		holochainSerializationRepositoryArgs := StandardRepositoryArgs(ctx, "holochain-serialization", &description)
+		holochainSerialization, err := NewHolochainRepository(ctx, "holochain-serialization", &holochainSerializationRepositoryArgs, pulumi.Import(pulumi.ID("holochain-serialization")))
 		if err != nil {
 			return err
 		}
@@ -489,24 +532,27 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "holochain-serialization", holochainSerialization); err != nil {
 			return err
 		}
-		holochainSerializationDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(holochainSerialization, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "holochain-serialization-default", &holochainSerializationDefaultRepositoryRulesetArgs); err != nil {
+		holochainSerializationDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(holochainSerialization, NewRulesetOptions(ctx, "holochain-serialization"))
+		if _, err = github.NewRepositoryRuleset(ctx, "holochain-serialization-default", &holochainSerializationDefaultRepositoryRulesetArgs, InRepository(ctx, "holochain-serialization")); err != nil {
 			return err
 		}
-		holochainSerializationReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(holochainSerialization, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "holochain-serialization-release", &holochainSerializationReleaseRepositoryRulesetArgs); err != nil {
+		holochainSerializationReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(holochainSerialization, NewRulesetOptions(ctx, "holochain-serialization"))
+		if _, err = github.NewRepositoryRuleset(ctx, "holochain-serialization-release", &holochainSerializationReleaseRepositoryRulesetArgs, InRepository(ctx, "holochain-serialization")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "holochain-serialization", holochainSerialization); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "holochain-serialization", holochainSerialization); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "holochain-serialization", holochainSerialization); err != nil {
-			return err
-		}
-		if err = AddCodeOwners(ctx, "holochain-serialization", holochainSerialization); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "holochain-serialization", holochainSerialization, DependabotConfig{EnableRust: true}); err != nil {
+		if err = AddSharedFiles(ctx, "holochain-serialization", holochainSerialization, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   description,
+				Ecosystems:    []Ecosystem{EcosystemRust},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot:        &DependabotConfig{EnableRust: true},
+		}); err != nil {
 			return err
 		}
 
@@ -514,8 +560,8 @@ func main() {
 		// Influxive
 		//
 		description = "Opinionated tools for working with InfluxDB from Rust"
-		influxiveRepositoryArgs := StandardRepositoryArgs("influxive", &description)
-		influxive, err := github.NewRepository(ctx, "influxive", &influxiveRepositoryArgs, pulumi.Import(pulumi.ID("influxive")))
+		influxiveRepositoryArgs := StandardRepositoryArgs(ctx, "influxive", &description)
+		influxive, err := NewHolochainRepository(ctx, "influxive", &influxiveRepositoryArgs, pulumi.Import(pulumi.ID("influxive")))
 		if err != nil {
 			return err
 		}
@@ -525,15 +571,15 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "influxive", influxive); err != nil {
 			return err
 		}
-		influxiveDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(influxive, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "influxive-default", &influxiveDefaultRepositoryRulesetArgs); err != nil {
+		influxiveDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(influxive, NewRulesetOptions(ctx, "influxive"))
+		if _, err = github.NewRepositoryRuleset(ctx, "influxive-default", &influxiveDefaultRepositoryRulesetArgs, InRepository(ctx, "influxive")); err != nil {
 			return err
 		}
-		influxiveReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(influxive, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "influxive-release", &influxiveReleaseRepositoryRulesetArgs); err != nil {
+		influxiveReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(influxive, NewRulesetOptions(ctx, "influxive"))
+		if _, err = github.NewRepositoryRuleset(ctx, "influxive-release", &influxiveReleaseRepositoryRulesetArgs, InRepository(ctx, "influxive")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "influxive", influxive); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "influxive", influxive); err != nil {
 			return err
 		}
 
@@ -541,14 +587,15 @@ func main() {
 		// Holochain Python Client
 		//
 		description = "A Python client for the Holochain Conductor API "
-		pythonClientRepositoryArgs := StandardRepositoryArgs("holochain-client-python", &description)
-		pythonClientRepositoryArgs.Topics = pulumi.StringArray{
-			pulumi.String("python"),
-			pulumi.String("python3"),
-			pulumi.String("holochain"),
-			pulumi.String("conductor-api"),
-		}
-		pythonClient, err := github.NewRepository(ctx, "holochain-client-python", &pythonClientRepositoryArgs, pulumi.Import(pulumi.ID("holochain-client-python")))
+		pythonClientRepositoryArgs := StandardRepositoryArgs(ctx, "holochain-client-python", &description,
+			Intentional("Topics", pulumi.StringArray{
+				pulumi.String("python"),
+				pulumi.String("python3"),
+				pulumi.String("holochain"),
+				pulumi.String("conductor-api"),
+			}, "Makes the client discoverable by Python developers"),
+		)
+		pythonClient, err := NewHolochainRepository(ctx, "holochain-client-python", &pythonClientRepositoryArgs, pulumi.Import(pulumi.ID("holochain-client-python")))
 		if err != nil {
 			return err
 		}
@@ -558,12 +605,28 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "holochain-client-python", pythonClient); err != nil {
 			return err
 		}
+		if err = AddSharedFiles(ctx, "holochain-client-python", pythonClient, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: description,
+			},
+			Dependabot: &DependabotConfig{
+				Updates: []DependabotUpdate{
+					{
+						Ecosystem: DependabotPip,
+						Schedule:  DependabotSchedule{Interval: "monthly"},
+						Groups:    []DependabotGroup{{Name: "pip", Patterns: []string{"*"}}},
+					},
+				},
+			},
+		}); err != nil {
+			return err
+		}
 
 		//
 		// Holochain Python Serialization
 		//
-		pythonSerializationRepositoryArgs := StandardRepositoryArgs("holochain-serialization-python", nil)
-		pythonSerialization, err := github.NewRepository(ctx, "holochain-serialization-python", &pythonSerializationRepositoryArgs, pulumi.Import(pulumi.ID("holochain-serialization-python")))
+		pythonSerializationRepositoryArgs := StandardRepositoryArgs(ctx, "holochain-serialization-python", nil)
+		pythonSerialization, err := NewHolochainRepository(ctx, "holochain-serialization-python", &pythonSerializationRepositoryArgs, pulumi.Import(pulumi.ID("holochain-serialization-python")))
 		if err != nil {
 			return err
 		}
@@ -577,8 +640,8 @@ func main() {
 		//
 		// Nix Cache Check
 		//
-		nixCacheCheckRepositoryArgs := StandardRepositoryArgs("nix-cache-check", nil)
-		nixCacheCheck, err := github.NewRepository(ctx, "nix-cache-check", &nixCacheCheckRepositoryArgs, pulumi.Import(pulumi.ID("nix-cache-check")))
+		nixCacheCheckRepositoryArgs := StandardRepositoryArgs(ctx, "nix-cache-check", nil)
+		nixCacheCheck, err := NewHolochainRepository(ctx, "nix-cache-check", &nixCacheCheckRepositoryArgs, pulumi.Import(pulumi.ID("nix-cache-check")))
 		if err != nil {
 			return err
 		}
@@ -588,20 +651,20 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "nix-cache-check", nixCacheCheck); err != nil {
 			return err
 		}
-		nixCacheCheckDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(nixCacheCheck, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "nix-cache-check-default", &nixCacheCheckDefaultRepositoryRulesetArgs); err != nil {
+		nixCacheCheckDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(nixCacheCheck, NewRulesetOptions(ctx, "nix-cache-check"))
+		if _, err = github.NewRepositoryRuleset(ctx, "nix-cache-check-default", &nixCacheCheckDefaultRepositoryRulesetArgs, InRepository(ctx, "nix-cache-check")); err != nil {
 			return err
 		}
-		nixCacheCheckReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(nixCacheCheck, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "nix-cache-check-release", &nixCacheCheckReleaseRepositoryRulesetArgs); err != nil {
+		nixCacheCheckReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(nixCacheCheck, NewRulesetOptions(ctx, "nix-cache-check"))
+		if _, err = github.NewRepositoryRuleset(ctx, "nix-cache-check-release", &nixCacheCheckReleaseRepositoryRulesetArgs, InRepository(ctx, "nix-cache-check")); err != nil {
 			return err
 		}
 
 		//
 		// junit-to-influx-action
 		//
-		junitToInfluxActionRepositoryArgs := StandardRepositoryArgs("junit-to-influx-action", nil)
-		junitToInfluxAction, err := github.NewRepository(ctx, "junit-to-influx-action", &junitToInfluxActionRepositoryArgs)
+		junitToInfluxActionRepositoryArgs := StandardRepositoryArgs(ctx, "junit-to-influx-action", nil)
+		junitToInfluxAction, err := NewHolochainRepository(ctx, "junit-to-influx-action", &junitToInfluxActionRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -611,24 +674,26 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "junit-to-influx-action", junitToInfluxAction); err != nil {
 			return err
 		}
-		junitToInfluxActionDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(junitToInfluxAction, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "junit-to-influx-action-default", &junitToInfluxActionDefaultRepositoryRulesetArgs); err != nil {
+		junitToInfluxActionDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(junitToInfluxAction, NewRulesetOptions(ctx, "junit-to-influx-action"))
+		if _, err = github.NewRepositoryRuleset(ctx, "junit-to-influx-action-default", &junitToInfluxActionDefaultRepositoryRulesetArgs, InRepository(ctx, "junit-to-influx-action")); err != nil {
 			return err
 		}
-		junitToInfluxActionReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(junitToInfluxAction, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "junit-to-influx-action-release", &junitToInfluxActionReleaseRepositoryRulesetArgs); err != nil {
+		junitToInfluxActionReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(junitToInfluxAction, NewRulesetOptions(ctx, "junit-to-influx-action"))
+		if _, err = github.NewRepositoryRuleset(ctx, "junit-to-influx-action-release", &junitToInfluxActionReleaseRepositoryRulesetArgs, InRepository(ctx, "junit-to-influx-action")); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "junit-to-influx-action", junitToInfluxAction); err != nil {
+		if err = AddSharedFiles(ctx, "junit-to-influx-action", junitToInfluxAction, SharedFilesConfig{
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
 		//
 		// Kitsune2
 		//
-		kitsune2RepositoryArgs := StandardRepositoryArgs("kitsune2", nil)
-		kitsune2RepositoryArgs.Description = pulumi.String("p2p / dht communication framework")
-		kitsune2, err := github.NewRepository(ctx, "kitsune2", &kitsune2RepositoryArgs, pulumi.Import(pulumi.ID("kitsune2")))
+		description = "p2p / dht communication framework"
+		kitsune2RepositoryArgs := StandardRepositoryArgs(ctx, "kitsune2", &description)
+		kitsune2, err := NewHolochainRepository(ctx, "kitsune2", &kitsune2RepositoryArgs, pulumi.Import(pulumi.ID("kitsune2")))
 		if err != nil {
 			return err
 		}
@@ -638,27 +703,29 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "kitsune2", kitsune2); err != nil {
 			return err
 		}
-		kitsune2RepositoryRulesetArgs := DefaultRepositoryRulesetArgs(kitsune2, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "kitsune2-default", &kitsune2RepositoryRulesetArgs); err != nil {
-			return err
-		}
-		kitsune2ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(kitsune2, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "kitsune2-release", &kitsune2ReleaseRepositoryRulesetArgs); err != nil {
+		kitsune2RepositoryRulesetArgs := DefaultRepositoryRulesetArgs(kitsune2, NewRulesetOptions(ctx, "kitsune2"))
+		if _, err = github.NewRepositoryRuleset(ctx, "kitsune2-default", &kitsune2RepositoryRulesetArgs, InRepository(ctx, "kitsune2")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "kitsune2", kitsune2); err != nil {
+		kitsune2ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(kitsune2, NewRulesetOptions(ctx, "kitsune2"))
+		if _, err = github.NewRepositoryRuleset(ctx, "kitsune2-release", &kitsune2ReleaseRepositoryRulesetArgs, InRepository(ctx, "kitsune2")); err != nil {
 			return err
 		}
-		if err = AddCachixAuthTokenSecret(ctx, conf, "kitsune2"); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "kitsune2", kitsune2); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "kitsune2", kitsune2); err != nil {
+		if err = AddCachixAuthTokenSecret(ctx, "kitsune2"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "kitsune2", kitsune2); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "kitsune2", kitsune2, DependabotConfig{EnableRust: true, EnableNix: true}); err != nil {
+		if err = AddSharedFiles(ctx, "kitsune2", kitsune2, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Ecosystems:    []Ecosystem{EcosystemRust, EcosystemNix},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot:        &DependabotConfig{EnableRust: true, EnableNix: true},
+		}); err != nil {
 			return err
 		}
 		if err = AddOutsideCollaborator(ctx, "kitsune2", kitsune2, "synchwire"); err != nil {
@@ -668,11 +735,12 @@ func main() {
 		//
 		// docs-pages
 		//
-		docsPagesRepositoryArgs := StandardRepositoryArgs("docs-pages", nil)
-		docsPagesRepositoryArgs.Description = pulumi.String("The hosted static files for the Holochain developer documentation")
-		docsPagesRepositoryArgs.HasDiscussions = pulumi.Bool(true)
-		docsPagesRepositoryArgs.HomepageUrl = pulumi.String("https://developer.holochain.org")
-		docsPages, err := github.NewRepository(ctx, "docs-pages", &docsPagesRepositoryArgs, pulumi.Import(pulumi.ID("docs-pages")))
+		description = "The hosted static files for the Holochain developer documentation"
+		docsPagesRepositoryArgs := StandardRepositoryArgs(ctx, "docs-pages", &description,
+			Intentional("HasDiscussions", pulumi.Bool(true), "Discussions are used for questions about the documentation"),
+			Intentional("HomepageUrl", pulumi.String("https://developer.holochain.org"), "Links to the hosted documentation"),
+		)
+		docsPages, err := NewHolochainRepository(ctx, "docs-pages", &docsPagesRepositoryArgs, pulumi.Import(pulumi.ID("docs-pages")))
 		if err != nil {
 			return err
 		}
@@ -682,7 +750,7 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "docs-pages", docsPages); err != nil {
 			return err
 		}
-		docsPagesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(docsPages, NewRulesetOptions().withExtraStatusChecks([]github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs{
+		docsPagesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(docsPages, NewRulesetOptions(ctx, "docs-pages").withExtraStatusChecks([]github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs{
 			{
 				IntegrationId: pulumi.Int(13473), // Netlify
 				Context:       pulumi.String("Header rules - developer-portal-production"),
@@ -696,7 +764,13 @@ func main() {
 				Context:       pulumi.String("Redirect rules - developer-portal-production"),
 			},
 		}))
-		if _, err = github.NewRepositoryRuleset(ctx, "docs-pages-default", &docsPagesDefaultRepositoryRulesetArgs); err != nil {
+		if _, err = github.NewRepositoryRuleset(ctx, "docs-pages-default", &docsPagesDefaultRepositoryRulesetArgs, InRepository(ctx, "docs-pages")); err != nil {
+			return err
+		}
+		if err = AddLabels(ctx, "docs-pages", docsPages, RepositoryLabelsConfig{
+			Sets:          []LabelSet{LabelSetGithubDefaults},
+			Authoritative: true,
+		}); err != nil {
 			return err
 		}
 
@@ -704,9 +778,10 @@ func main() {
 		// scaffolding
 		//
 		scaffoldingDescription := "Scaffolding tool to quickly generate and modify holochain applications"
-		scaffoldingRepositoryArgs := StandardRepositoryArgs("scaffolding", &scaffoldingDescription)
-		scaffoldingRepositoryArgs.HomepageUrl = pulumi.String("https://docs.rs/holochain_scaffolding_cli")
-		scaffolding, err := github.NewRepository(ctx, "scaffolding", &scaffoldingRepositoryArgs, pulumi.Import(pulumi.ID("scaffolding")))
+		scaffoldingRepositoryArgs := StandardRepositoryArgs(ctx, "scaffolding", &scaffoldingDescription,
+			Intentional("HomepageUrl", pulumi.String("https://docs.rs/holochain_scaffolding_cli"), "Links to the CLI documentation"),
+		)
+		scaffolding, err := NewHolochainRepository(ctx, "scaffolding", &scaffoldingRepositoryArgs, pulumi.Import(pulumi.ID("scaffolding")))
 		if err != nil {
 			return err
 		}
@@ -716,32 +791,35 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "scaffolding", scaffolding); err != nil {
 			return err
 		}
-		scaffoldingDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(scaffolding, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "scaffolding-default", &scaffoldingDefaultRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		scaffoldingReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(scaffolding, NewRulesetOptions().noLinearHistoryRequired())
-		if _, err = github.NewRepositoryRuleset(ctx, "scaffolding-release", &scaffoldingReleaseRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "scaffolding", scaffolding); err != nil {
+		scaffoldingDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(scaffolding, NewRulesetOptions(ctx, "scaffolding"))
+		if _, err = github.NewRepositoryRuleset(ctx, "scaffolding-default", &scaffoldingDefaultRepositoryRulesetArgs, InRepository(ctx, "scaffolding")); err != nil {
 			return err
 		}
-		if err = AddCachixAuthTokenSecret(ctx, conf, "scaffolding"); err != nil {
+		scaffoldingReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(scaffolding, NewRulesetOptions(ctx, "scaffolding").noLinearHistoryRequired())
+		if _, err = github.NewRepositoryRuleset(ctx, "scaffolding-release", &scaffoldingReleaseRepositoryRulesetArgs, InRepository(ctx, "scaffolding")); err != nil {
 			return err
 		}
-		if err = AddHolochainBackportLabels(ctx, "scaffolding", scaffolding); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "scaffolding", scaffolding); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "scaffolding", scaffolding); err != nil {
+		if err = AddCachixAuthTokenSecret(ctx, "scaffolding"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "scaffolding", scaffolding); err != nil {
+		if err = AddReleaseLines(ctx, "scaffolding", scaffolding, ReleaseLinesConfig{}); err != nil {
 			return err
 		}
-		if err = AddDependabotYml(ctx, "scaffolding", scaffolding, DependabotConfig{
-			EnableRust: true,
-			EnableNix:  true,
+		if err = AddSharedFiles(ctx, "scaffolding", scaffolding, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   scaffoldingDescription,
+				Ecosystems:    []Ecosystem{EcosystemRust, EcosystemNix},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{
+				EnableRust: true,
+				EnableNix:  true,
+			},
 		}); err != nil {
 			return err
 		}
@@ -750,8 +828,8 @@ func main() {
 		// hc-launch
 		//
 		hcLaunchDescription := "tauri based CLI to run holochain apps in development mode"
-		hcLaunchRepositoryArgs := StandardRepositoryArgs("hc-launch", &hcLaunchDescription)
-		hcLaunch, err := github.NewRepository(ctx, "hc-launch", &hcLaunchRepositoryArgs, pulumi.Import(pulumi.ID("hc-launch")))
+		hcLaunchRepositoryArgs := StandardRepositoryArgs(ctx, "hc-launch", &hcLaunchDescription)
+		hcLaunch, err := NewHolochainRepository(ctx, "hc-launch", &hcLaunchRepositoryArgs, pulumi.Import(pulumi.ID("hc-launch")))
 		if err != nil {
 			return err
 		}
@@ -761,12 +839,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-launch", hcLaunch); err != nil {
 			return err
 		}
-		hcLaunchDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcLaunch, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-launch-default", &hcLaunchDefaultRepositoryRulesetArgs); err != nil {
+		hcLaunchDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcLaunch, NewRulesetOptions(ctx, "hc-launch"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-launch-default", &hcLaunchDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-launch")); err != nil {
 			return err
 		}
-		hcLaunchReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcLaunch, NewRulesetOptions().noLinearHistoryRequired())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-launch-release", &hcLaunchReleaseRepositoryRulesetArgs); err != nil {
+		hcLaunchReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcLaunch, NewRulesetOptions(ctx, "hc-launch").noLinearHistoryRequired())
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-launch-release", &hcLaunchReleaseRepositoryRulesetArgs, InRepository(ctx, "hc-launch")); err != nil {
 			return err
 		}
 
@@ -774,8 +852,8 @@ func main() {
 		// hc-spin
 		//
 		hcSpinDescription := "CLI to run Holochain Apps in Development Mode"
-		hcSpinRepositoryArgs := StandardRepositoryArgs("hc-spin", &hcSpinDescription)
-		hcSpin, err := github.NewRepository(ctx, "hc-spin", &hcSpinRepositoryArgs, pulumi.Import(pulumi.ID("hc-spin")))
+		hcSpinRepositoryArgs := StandardRepositoryArgs(ctx, "hc-spin", &hcSpinDescription)
+		hcSpin, err := NewHolochainRepository(ctx, "hc-spin", &hcSpinRepositoryArgs, pulumi.Import(pulumi.ID("hc-spin")))
 		if err != nil {
 			return err
 		}
@@ -785,24 +863,28 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-spin", hcSpin); err != nil {
 			return err
 		}
-		hcSpinDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcSpin, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-default", &hcSpinDefaultRepositoryRulesetArgs); err != nil {
+		hcSpinDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcSpin, NewRulesetOptions(ctx, "hc-spin"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-default", &hcSpinDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-spin")); err != nil {
 			return err
 		}
-		hcSpinReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcSpin, NewRulesetOptions().noLinearHistoryRequired())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-release", &hcSpinReleaseRepositoryRulesetArgs); err != nil {
+		hcSpinReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcSpin, NewRulesetOptions(ctx, "hc-spin").noLinearHistoryRequired())
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-release", &hcSpinReleaseRepositoryRulesetArgs, InRepository(ctx, "hc-spin")); err != nil {
 			return err
 		}
-		if err = AddHolochainBackportLabels(ctx, "hc-spin", hcSpin); err != nil {
+		if err = AddReleaseLines(ctx, "hc-spin", hcSpin, ReleaseLinesConfig{}); err != nil {
 			return err
 		}
-		if err = AddGithubUserTokenSecret(ctx, conf, "hc-spin"); err != nil {
+		if err = AddGithubToken(ctx, "hc-spin", GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "hc-spin", hcSpin); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "hc-spin", hcSpin, DependabotConfig{EnableNpm: true}); err != nil {
+		if err = AddSharedFiles(ctx, "hc-spin", hcSpin, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: hcSpinDescription,
+				Ecosystems:  []Ecosystem{EcosystemNpm},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{EnableNpm: true},
+		}); err != nil {
 			return err
 		}
 
@@ -810,8 +892,8 @@ func main() {
 		// hc-spin-rust-utils
 		//
 		hcSpinRustUtilsDescription := "Rust node add-ons for hc-spin"
-		hcSpinRustUtilsRepositoryArgs := StandardRepositoryArgs("hc-spin-rust-utils", &hcSpinRustUtilsDescription)
-		hcSpinRustUtils, err := github.NewRepository(ctx, "hc-spin-rust-utils", &hcSpinRustUtilsRepositoryArgs, pulumi.Import(pulumi.ID("hc-spin-rust-utils")))
+		hcSpinRustUtilsRepositoryArgs := StandardRepositoryArgs(ctx, "hc-spin-rust-utils", &hcSpinRustUtilsDescription)
+		hcSpinRustUtils, err := NewHolochainRepository(ctx, "hc-spin-rust-utils", &hcSpinRustUtilsRepositoryArgs, pulumi.Import(pulumi.ID("hc-spin-rust-utils")))
 		if err != nil {
 			return err
 		}
@@ -821,24 +903,28 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-spin-rust-utils", hcSpinRustUtils); err != nil {
 			return err
 		}
-		hcSpinRustUtilsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcSpinRustUtils, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-rust-utils-default", &hcSpinRustUtilsDefaultRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		hcSpinRustUtilsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcSpinRustUtils, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-rust-utils-release", &hcSpinRustUtilsReleaseRepositoryRulesetArgs); err != nil {
+		hcSpinRustUtilsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcSpinRustUtils, NewRulesetOptions(ctx, "hc-spin-rust-utils"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-rust-utils-default", &hcSpinRustUtilsDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-spin-rust-utils")); err != nil {
 			return err
 		}
-		if err = AddGithubUserTokenSecret(ctx, conf, "hc-spin-rust-utils"); err != nil {
+		hcSpinRustUtilsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcSpinRustUtils, NewRulesetOptions(ctx, "hc-spin-rust-utils"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-spin-rust-utils-release", &hcSpinRustUtilsReleaseRepositoryRulesetArgs, InRepository(ctx, "hc-spin-rust-utils")); err != nil {
 			return err
 		}
-		if err = AddHolochainBackportLabels(ctx, "hc-spin-rust-utils", hcSpinRustUtils); err != nil {
+		if err = AddGithubToken(ctx, "hc-spin-rust-utils", GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "hc-spin-rust-utils", hcSpinRustUtils); err != nil {
+		if err = AddReleaseLines(ctx, "hc-spin-rust-utils", hcSpinRustUtils, ReleaseLinesConfig{}); err != nil {
 			return err
 		}
-		if err = AddDependabotYml(ctx, "hc-spin-rust-utils", hcSpinRustUtils, DependabotConfig{EnableNpm: true}); err != nil {
+		if err = AddSharedFiles(ctx, "hc-spin-rust-utils", hcSpinRustUtils, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: hcSpinRustUtilsDescription,
+				Ecosystems:  []Ecosystem{EcosystemNpm},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{EnableNpm: true},
+		}); err != nil {
 			return err
 		}
 
@@ -846,9 +932,10 @@ func main() {
 		// kangaroo-electron
 		//
 		kangarooElectronDescription := "Bundle your holochain app a a standalone electron app with a built-in conductor"
-		kangarooElectronRepositoryArgs := StandardRepositoryArgs("kangaroo-electron", &kangarooElectronDescription)
-		kangarooElectronRepositoryArgs.IsTemplate = pulumi.Bool(true)
-		kangarooElectron, err := github.NewRepository(ctx, "kangaroo-electron", &kangarooElectronRepositoryArgs, pulumi.Import(pulumi.ID("kangaroo-electron")))
+		kangarooElectronRepositoryArgs := StandardRepositoryArgs(ctx, "kangaroo-electron", &kangarooElectronDescription,
+			Intentional("IsTemplate", pulumi.Bool(true), "Apps are created from kangaroo-electron as a template"),
+		)
+		kangarooElectron, err := NewHolochainRepository(ctx, "kangaroo-electron", &kangarooElectronRepositoryArgs, pulumi.Import(pulumi.ID("kangaroo-electron")))
 		if err != nil {
 			return err
 		}
@@ -859,22 +946,22 @@ func main() {
 			return err
 		}
 		// Since kangaroo is a Github Template we currently omit mandatory CI checks
-		kangarooElectronDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(kangarooElectron, NewRulesetOptions().noStatusChecks())
-		if _, err = github.NewRepositoryRuleset(ctx, "kangaroo-electron-default", &kangarooElectronDefaultRepositoryRulesetArgs); err != nil {
+		kangarooElectronDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(kangarooElectron, NewRulesetOptions(ctx, "kangaroo-electron").noStatusChecks())
+		if _, err = github.NewRepositoryRuleset(ctx, "kangaroo-electron-default", &kangarooElectronDefaultRepositoryRulesetArgs, InRepository(ctx, "kangaroo-electron")); err != nil {
 			return err
 		}
 		// Since kangaroo is a Github Template we currently omit mandatory CI checks
-		kangarooElectronReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(kangarooElectron, NewRulesetOptions().noStatusChecks().noLinearHistoryRequired())
-		if _, err = github.NewRepositoryRuleset(ctx, "kangaroo-electron-release", &kangarooElectronReleaseRepositoryRulesetArgs); err != nil {
+		kangarooElectronReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(kangarooElectron, NewRulesetOptions(ctx, "kangaroo-electron").noStatusChecks().noLinearHistoryRequired())
+		if _, err = github.NewRepositoryRuleset(ctx, "kangaroo-electron-release", &kangarooElectronReleaseRepositoryRulesetArgs, InRepository(ctx, "kangaroo-electron")); err != nil {
 			return err
 		}
-		if err = AddAppleAppSigningSecrets(ctx, conf, "kangaroo-electron"); err != nil {
+		if err = AddAppleAppSigningSecrets(ctx, "kangaroo-electron"); err != nil {
 			return err
 		}
-		if err = AddWindowsCodeSigningCertificates(ctx, conf, "kangaroo-electron"); err != nil {
+		if err = AddWindowsCodeSigningCertificates(ctx, "kangaroo-electron"); err != nil {
 			return err
 		}
-		if err = AddHolochainBackportLabels(ctx, "kangaroo-electron", kangarooElectron); err != nil {
+		if err = AddReleaseLines(ctx, "kangaroo-electron", kangarooElectron, ReleaseLinesConfig{}); err != nil {
 			return err
 		}
 
@@ -882,8 +969,8 @@ func main() {
 		// Dino Adventure
 		//
 		dinoAdventureDescription := "A dinosaur adventure game for testing Holochain"
-		dinoAdventureRepositoryArgs := StandardRepositoryArgs("dino-adventure", &dinoAdventureDescription)
-		dinoAdventure, err := github.NewRepository(ctx, "dino-adventure", &dinoAdventureRepositoryArgs)
+		dinoAdventureRepositoryArgs := StandardRepositoryArgs(ctx, "dino-adventure", &dinoAdventureDescription)
+		dinoAdventure, err := NewHolochainRepository(ctx, "dino-adventure", &dinoAdventureRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -893,15 +980,20 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "dino-adventure", dinoAdventure); err != nil {
 			return err
 		}
-		dinoAdventureDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(dinoAdventure, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "dino-adventure-default", &dinoAdventureDefaultRepositoryRulesetArgs); err != nil {
+		dinoAdventureDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(dinoAdventure, NewRulesetOptions(ctx, "dino-adventure"))
+		if _, err = github.NewRepositoryRuleset(ctx, "dino-adventure-default", &dinoAdventureDefaultRepositoryRulesetArgs, InRepository(ctx, "dino-adventure")); err != nil {
 			return err
 		}
-		dinoAdventureReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(dinoAdventure, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "dino-adventure-release", &dinoAdventureReleaseRepositoryRulesetArgs); err != nil {
+		dinoAdventureReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(dinoAdventure, NewRulesetOptions(ctx, "dino-adventure"))
+		if _, err = github.NewRepositoryRuleset(ctx, "dino-adventure-release", &dinoAdventureReleaseRepositoryRulesetArgs, InRepository(ctx, "dino-adventure")); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "dino-adventure", dinoAdventure); err != nil {
+		if err = AddSharedFiles(ctx, "dino-adventure", dinoAdventure, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: dinoAdventureDescription,
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -909,8 +1001,8 @@ func main() {
 		// Dino Adventure - Kangaroo
 		//
 		dinoAdventureKangarooDescription := "Kangaroo packaging for the dino adventure app"
-		dinoAdventureKangarooRepositoryArgs := StandardRepositoryArgs("dino-adventure-kangaroo", &dinoAdventureKangarooDescription)
-		dinoAdventureKangaroo, err := github.NewRepository(ctx, "dino-adventure-kangaroo", &dinoAdventureKangarooRepositoryArgs, pulumi.Import(pulumi.ID("dino-adventure-kangaroo")))
+		dinoAdventureKangarooRepositoryArgs := StandardRepositoryArgs(ctx, "dino-adventure-kangaroo", &dinoAdventureKangarooDescription)
+		dinoAdventureKangaroo, err := NewHolochainRepository(ctx, "dino-adventure-kangaroo", &dinoAdventureKangarooRepositoryArgs, pulumi.Import(pulumi.ID("dino-adventure-kangaroo")))
 		if err != nil {
 			return err
 		}
@@ -920,10 +1012,10 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "dino-adventure-kangaroo", dinoAdventureKangaroo); err != nil {
 			return err
 		}
-		if err = AddAppleAppSigningSecrets(ctx, conf, "dino-adventure-kangaroo"); err != nil {
+		if err = AddAppleAppSigningSecrets(ctx, "dino-adventure-kangaroo"); err != nil {
 			return err
 		}
-		if err = AddWindowsCodeSigningCertificates(ctx, conf, "dino-adventure-kangaroo"); err != nil {
+		if err = AddWindowsCodeSigningCertificates(ctx, "dino-adventure-kangaroo"); err != nil {
 			return err
 		}
 
@@ -931,8 +1023,8 @@ func main() {
 		// nomad-server
 		//
 		nomadServerDescription := "A Pulumi definition for deploying a cluster of Nomad servers as DigitalOcean droplets"
-		nomadServerRepositoryArgs := StandardRepositoryArgs("nomad-server", &nomadServerDescription)
-		nomadServer, err := github.NewRepository(ctx, "nomad-server", &nomadServerRepositoryArgs)
+		nomadServerRepositoryArgs := StandardRepositoryArgs(ctx, "nomad-server", &nomadServerDescription)
+		nomadServer, err := NewHolochainRepository(ctx, "nomad-server", &nomadServerRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -942,17 +1034,23 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "nomad-server", nomadServer); err != nil {
 			return err
 		}
-		nomadServerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(nomadServer, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "nomad-server-default", &nomadServerDefaultRepositoryRulesetArgs); err != nil {
+		nomadServerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(nomadServer, NewRulesetOptions(ctx, "nomad-server"))
+		if _, err = github.NewRepositoryRuleset(ctx, "nomad-server-default", &nomadServerDefaultRepositoryRulesetArgs, InRepository(ctx, "nomad-server")); err != nil {
 			return err
 		}
-		if err = AddGithubUserTokenSecret(ctx, conf, "nomad-server"); err != nil {
+		if err = AddGithubToken(ctx, "nomad-server", GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 			return err
 		}
-		if err = AddPulumiAccessTokenSecret(ctx, conf, "nomad-server"); err != nil {
+		if err = AddPulumiAccessTokenSecret(ctx, "nomad-server"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "nomad-server", nomadServer); err != nil {
+		if err = AddSharedFiles(ctx, "nomad-server", nomadServer, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: nomadServerDescription,
+				Ecosystems:  []Ecosystem{EcosystemGo},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -960,8 +1058,8 @@ func main() {
 		// hc-http-gw
 		//
 		hcHttpGwDescription := "The Holochain HTTP Gateway for providing a way to bridge from the web2 world into Holochain"
-		hcHttpGwRepositoryArgs := StandardRepositoryArgs("hc-http-gw", &hcHttpGwDescription)
-		hcHttpGw, err := github.NewRepository(ctx, "hc-http-gw", &hcHttpGwRepositoryArgs)
+		hcHttpGwRepositoryArgs := StandardRepositoryArgs(ctx, "hc-http-gw", &hcHttpGwDescription)
+		hcHttpGw, err := NewHolochainRepository(ctx, "hc-http-gw", &hcHttpGwRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -971,24 +1069,27 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-http-gw", hcHttpGw); err != nil {
 			return err
 		}
-		hcHttpGwDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcHttpGw, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-http-gw-default", &hcHttpGwDefaultRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		hcHttpGwReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcHttpGw, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-http-gw-release", &hcHttpGwReleaseRepositoryRulesetArgs); err != nil {
+		hcHttpGwDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcHttpGw, NewRulesetOptions(ctx, "hc-http-gw"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-http-gw-default", &hcHttpGwDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-http-gw")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "hc-http-gw", hcHttpGw); err != nil {
+		hcHttpGwReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcHttpGw, NewRulesetOptions(ctx, "hc-http-gw"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-http-gw-release", &hcHttpGwReleaseRepositoryRulesetArgs, InRepository(ctx, "hc-http-gw")); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "hc-http-gw", hcHttpGw); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "hc-http-gw", hcHttpGw); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "hc-http-gw", hcHttpGw); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "hc-http-gw", hcHttpGw, DependabotConfig{EnableRust: true, EnableNix: true}); err != nil {
+		if err = AddSharedFiles(ctx, "hc-http-gw", hcHttpGw, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   hcHttpGwDescription,
+				Ecosystems:    []Ecosystem{EcosystemRust, EcosystemNix},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot:        &DependabotConfig{EnableRust: true, EnableNix: true},
+		}); err != nil {
 			return err
 		}
 
@@ -996,8 +1097,8 @@ func main() {
 		// network-services
 		//
 		networkServicesDescription := "A Pulumi definition for deploying Holochain network services to be used for development"
-		networkServicesRepositoryArgs := StandardRepositoryArgs("network-services", &networkServicesDescription)
-		networkServices, err := github.NewRepository(ctx, "network-services", &networkServicesRepositoryArgs)
+		networkServicesRepositoryArgs := StandardRepositoryArgs(ctx, "network-services", &networkServicesDescription)
+		networkServices, err := NewHolochainRepository(ctx, "network-services", &networkServicesRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1007,17 +1108,23 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "network-services", networkServices); err != nil {
 			return err
 		}
-		networkServicesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(networkServices, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "network-services-default", &networkServicesDefaultRepositoryRulesetArgs); err != nil {
+		networkServicesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(networkServices, NewRulesetOptions(ctx, "network-services"))
+		if _, err = github.NewRepositoryRuleset(ctx, "network-services-default", &networkServicesDefaultRepositoryRulesetArgs, InRepository(ctx, "network-services")); err != nil {
 			return err
 		}
-		if err = AddGithubUserTokenSecret(ctx, conf, "network-services"); err != nil {
+		if err = AddGithubToken(ctx, "network-services", GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 			return err
 		}
-		if err = AddPulumiAccessTokenSecret(ctx, conf, "network-services"); err != nil {
+		if err = AddPulumiAccessTokenSecret(ctx, "network-services"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "network-services", networkServices); err != nil {
+		if err = AddSharedFiles(ctx, "network-services", networkServices, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: networkServicesDescription,
+				Ecosystems:  []Ecosystem{EcosystemGo},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -1025,8 +1132,8 @@ func main() {
 		// pulumi-network-services
 		//
 		pulumiNetworkServicesDescription := "Common components for deploying Holochain network services"
-		pulumiNetworkServicesRepositoryArgs := StandardRepositoryArgs("pulumi-network-services", &pulumiNetworkServicesDescription)
-		pulumiNetworkServices, err := github.NewRepository(ctx, "pulumi-network-services", &pulumiNetworkServicesRepositoryArgs)
+		pulumiNetworkServicesRepositoryArgs := StandardRepositoryArgs(ctx, "pulumi-network-services", &pulumiNetworkServicesDescription)
+		pulumiNetworkServices, err := NewHolochainRepository(ctx, "pulumi-network-services", &pulumiNetworkServicesRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1036,17 +1143,22 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "pulumi-network-services", pulumiNetworkServices); err != nil {
 			return err
 		}
-		pulumiNetworkServicesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(pulumiNetworkServices, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "pulumi-network-services-default", &pulumiNetworkServicesDefaultRepositoryRulesetArgs); err != nil {
-			return err
-		}
-		if err = AddGoReleaseSupport(ctx, conf, "pulumi-network-services", pulumiNetworkServices); err != nil {
+		pulumiNetworkServicesDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(pulumiNetworkServices, NewRulesetOptions(ctx, "pulumi-network-services"))
+		if _, err = github.NewRepositoryRuleset(ctx, "pulumi-network-services-default", &pulumiNetworkServicesDefaultRepositoryRulesetArgs, InRepository(ctx, "pulumi-network-services")); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "pulumi-network-services", pulumiNetworkServices); err != nil {
+		if err = AddGoReleaseSupport(ctx, "pulumi-network-services", pulumiNetworkServices); err != nil {
 			return err
 		}
-		if err = AddDependabotYml(ctx, "pulumi-network-services", pulumiNetworkServices, DependabotConfig{EnableGo: true, EnableNix: true}); err != nil {
+		if err = AddSharedFiles(ctx, "pulumi-network-services", pulumiNetworkServices, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   pulumiNetworkServicesDescription,
+				Ecosystems:    []Ecosystem{EcosystemGo, EcosystemNix},
+				ReleaseScheme: ReleaseSchemeGo,
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{EnableGo: true, EnableNix: true},
+		}); err != nil {
 			return err
 		}
 
@@ -1054,8 +1166,8 @@ func main() {
 		// wind-tunnel-runner
 		//
 		windTunnelRunnerDescription := "The guide and NixOS configuration for setting up a machine to run Wind Tunnel scenarios"
-		windTunnelRunnerRepositoryArgs := StandardRepositoryArgs("wind-tunnel-runner", &windTunnelRunnerDescription)
-		windTunnelRunner, err := github.NewRepository(ctx, "wind-tunnel-runner", &windTunnelRunnerRepositoryArgs)
+		windTunnelRunnerRepositoryArgs := StandardRepositoryArgs(ctx, "wind-tunnel-runner", &windTunnelRunnerDescription)
+		windTunnelRunner, err := NewHolochainRepository(ctx, "wind-tunnel-runner", &windTunnelRunnerRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1065,20 +1177,26 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "wind-tunnel-runner", windTunnelRunner); err != nil {
 			return err
 		}
-		windTunnelRunnerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(windTunnelRunner, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-runner-default", &windTunnelRunnerDefaultRepositoryRulesetArgs); err != nil {
+		windTunnelRunnerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(windTunnelRunner, NewRulesetOptions(ctx, "wind-tunnel-runner"))
+		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-runner-default", &windTunnelRunnerDefaultRepositoryRulesetArgs, InRepository(ctx, "wind-tunnel-runner")); err != nil {
 			return err
 		}
-		if err = AddTailscaleOAuthSecrets(ctx, conf, "wind-tunnel-runner"); err != nil {
+		if err = AddTailscaleOAuthSecrets(ctx, "wind-tunnel-runner"); err != nil {
 			return err
 		}
-		if err = AddCachixAuthTokenSecret(ctx, conf, "wind-tunnel-runner"); err != nil {
+		if err = AddCachixAuthTokenSecret(ctx, "wind-tunnel-runner"); err != nil {
 			return err
 		}
-		if err = AddThreefoldHubApiToken(ctx, conf, "wind-tunnel-runner"); err != nil {
+		if err = AddThreefoldHubApiToken(ctx, "wind-tunnel-runner"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "wind-tunnel-runner", windTunnelRunner); err != nil {
+		if err = AddSharedFiles(ctx, "wind-tunnel-runner", windTunnelRunner, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: windTunnelRunnerDescription,
+				Ecosystems:  []Ecosystem{EcosystemNix},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -1086,8 +1204,8 @@ func main() {
 		// must_future
 		//
 		mustFutureDescription := "A wrapper future marked must_use - mainly to wrap BoxFutures"
-		mustFutureRepositoryArgs := StandardRepositoryArgs("must_future", &mustFutureDescription)
-		mustFuture, err := github.NewRepository(ctx, "must_future", &mustFutureRepositoryArgs, pulumi.Import(pulumi.ID("must_future")))
+		mustFutureRepositoryArgs := StandardRepositoryArgs(ctx, "must_future", &mustFutureDescription)
+		mustFuture, err := NewHolochainRepository(ctx, "must_future", &mustFutureRepositoryArgs, pulumi.Import(pulumi.ID("must_future")))
 		if err != nil {
 			return err
 		}
@@ -1097,12 +1215,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "must_future", mustFuture); err != nil {
 			return err
 		}
-		mustFutureDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(mustFuture, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "must_future-default", &mustFutureDefaultRepositoryRulesetArgs); err != nil {
+		mustFutureDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(mustFuture, NewRulesetOptions(ctx, "must_future"))
+		if _, err = github.NewRepositoryRuleset(ctx, "must_future-default", &mustFutureDefaultRepositoryRulesetArgs, InRepository(ctx, "must_future")); err != nil {
 			return err
 		}
-		mustFutureReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(mustFuture, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "must_future-release", &mustFutureReleaseRepositoryRulesetArgs); err != nil {
+		mustFutureReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(mustFuture, NewRulesetOptions(ctx, "must_future"))
+		if _, err = github.NewRepositoryRuleset(ctx, "must_future-release", &mustFutureReleaseRepositoryRulesetArgs, InRepository(ctx, "must_future")); err != nil {
 			return err
 		}
 
@@ -1110,8 +1228,8 @@ func main() {
 		// url2
 		//
 		url2Description := "ergonomic wrapper around the popular url crate"
-		url2RepositoryArgs := StandardRepositoryArgs("url2", &url2Description)
-		url2, err := github.NewRepository(ctx, "url2", &url2RepositoryArgs, pulumi.Import(pulumi.ID("url2")))
+		url2RepositoryArgs := StandardRepositoryArgs(ctx, "url2", &url2Description)
+		url2, err := NewHolochainRepository(ctx, "url2", &url2RepositoryArgs, pulumi.Import(pulumi.ID("url2")))
 		if err != nil {
 			return err
 		}
@@ -1121,15 +1239,21 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "url2", url2); err != nil {
 			return err
 		}
-		url2DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(url2, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "url2-default", &url2DefaultRepositoryRulesetArgs); err != nil {
+		url2DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(url2, NewRulesetOptions(ctx, "url2"))
+		if _, err = github.NewRepositoryRuleset(ctx, "url2-default", &url2DefaultRepositoryRulesetArgs, InRepository(ctx, "url2")); err != nil {
 			return err
 		}
-		url2ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(url2, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "url2-release", &url2ReleaseRepositoryRulesetArgs); err != nil {
+		url2ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(url2, NewRulesetOptions(ctx, "url2"))
+		if _, err = github.NewRepositoryRuleset(ctx, "url2-release", &url2ReleaseRepositoryRulesetArgs, InRepository(ctx, "url2")); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "url2", url2); err != nil {
+		if err = AddSharedFiles(ctx, "url2", url2, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: url2Description,
+				Ecosystems:  []Ecosystem{EcosystemRust},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -1137,8 +1261,8 @@ func main() {
 		// automap-rs
 		//
 		automapRsDescription := "Simple pattern for expressing Rust maps where the Value type contains the Key"
-		automapRsRepositoryArgs := StandardRepositoryArgs("automap-rs", &automapRsDescription)
-		automapRs, err := github.NewRepository(ctx, "automap-rs", &automapRsRepositoryArgs, pulumi.Import(pulumi.ID("automap-rs")))
+		automapRsRepositoryArgs := StandardRepositoryArgs(ctx, "automap-rs", &automapRsDescription)
+		automapRs, err := NewHolochainRepository(ctx, "automap-rs", &automapRsRepositoryArgs, pulumi.Import(pulumi.ID("automap-rs")))
 		if err != nil {
 			return err
 		}
@@ -1148,12 +1272,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "automap-rs", automapRs); err != nil {
 			return err
 		}
-		automapRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(automapRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "automap-rs-default", &automapRsDefaultRepositoryRulesetArgs); err != nil {
+		automapRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(automapRs, NewRulesetOptions(ctx, "automap-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "automap-rs-default", &automapRsDefaultRepositoryRulesetArgs, InRepository(ctx, "automap-rs")); err != nil {
 			return err
 		}
-		automapRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(automapRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "automap-rs-release", &automapRsReleaseRepositoryRulesetArgs); err != nil {
+		automapRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(automapRs, NewRulesetOptions(ctx, "automap-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "automap-rs-release", &automapRsReleaseRepositoryRulesetArgs, InRepository(ctx, "automap-rs")); err != nil {
 			return err
 		}
 
@@ -1161,8 +1285,8 @@ func main() {
 		// rand-utf8
 		//
 		randUtf8Description := "Random utf8 utility"
-		randUtf8RepositoryArgs := StandardRepositoryArgs("rand-utf8", &randUtf8Description)
-		randUtf8, err := github.NewRepository(ctx, "rand-utf8", &randUtf8RepositoryArgs, pulumi.Import(pulumi.ID("rand-utf8")))
+		randUtf8RepositoryArgs := StandardRepositoryArgs(ctx, "rand-utf8", &randUtf8Description)
+		randUtf8, err := NewHolochainRepository(ctx, "rand-utf8", &randUtf8RepositoryArgs, pulumi.Import(pulumi.ID("rand-utf8")))
 		if err != nil {
 			return err
 		}
@@ -1172,18 +1296,24 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "rand-utf8", randUtf8); err != nil {
 			return err
 		}
-		randUtf8DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(randUtf8, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "rand-utf8-default", &randUtf8DefaultRepositoryRulesetArgs); err != nil {
+		randUtf8DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(randUtf8, NewRulesetOptions(ctx, "rand-utf8"))
+		if _, err = github.NewRepositoryRuleset(ctx, "rand-utf8-default", &randUtf8DefaultRepositoryRulesetArgs, InRepository(ctx, "rand-utf8")); err != nil {
 			return err
 		}
-		randUtf8ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(randUtf8, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "rand-utf8-release", &randUtf8ReleaseRepositoryRulesetArgs); err != nil {
+		randUtf8ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(randUtf8, NewRulesetOptions(ctx, "rand-utf8"))
+		if _, err = github.NewRepositoryRuleset(ctx, "rand-utf8-release", &randUtf8ReleaseRepositoryRulesetArgs, InRepository(ctx, "rand-utf8")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "rand-utf8", randUtf8); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "rand-utf8", randUtf8); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "rand-utf8", randUtf8); err != nil {
+		if err = AddSharedFiles(ctx, "rand-utf8", randUtf8, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   randUtf8Description,
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+		}); err != nil {
 			return err
 		}
 
@@ -1191,23 +1321,23 @@ func main() {
 		// serde-json
 		//
 		serdeJsonDescription := "Strongly typed JSON library for Rust"
-		serdeJsonRepositoryArgs := StandardRepositoryArgs("serde-json", &serdeJsonDescription)
-		serdeJson, err := github.NewRepository(ctx, "serde-json", &serdeJsonRepositoryArgs, pulumi.Import(pulumi.ID("serde-json")))
+		serdeJsonRepositoryArgs := StandardRepositoryArgs(ctx, "serde-json", &serdeJsonDescription)
+		serdeJson, err := NewHolochainRepository(ctx, "serde-json", &serdeJsonRepositoryArgs, pulumi.Import(pulumi.ID("serde-json")))
 		if err != nil {
 			return err
 		}
 		if err = StandardRepositoryAccess(ctx, "serde-json", serdeJson); err != nil {
 			return err
 		}
-		serdeJsonDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(serdeJson, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "serde-json-default", &serdeJsonDefaultRepositoryRulesetArgs); err != nil {
+		serdeJsonDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(serdeJson, NewRulesetOptions(ctx, "serde-json"))
+		if _, err = github.NewRepositoryRuleset(ctx, "serde-json-default", &serdeJsonDefaultRepositoryRulesetArgs, InRepository(ctx, "serde-json")); err != nil {
 			return err
 		}
-		serdeJsonReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(serdeJson, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "serde-json-release", &serdeJsonReleaseRepositoryRulesetArgs); err != nil {
+		serdeJsonReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(serdeJson, NewRulesetOptions(ctx, "serde-json"))
+		if _, err = github.NewRepositoryRuleset(ctx, "serde-json-release", &serdeJsonReleaseRepositoryRulesetArgs, InRepository(ctx, "serde-json")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "serde-json", serdeJson); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "serde-json", serdeJson); err != nil {
 			return err
 		}
 
@@ -1215,8 +1345,8 @@ func main() {
 		// isotest-rs
 		//
 		isoTestRsDescription := "Opinionated way to solve a very particular problem in Rust testing"
-		isoTestRsRepositoryArgs := StandardRepositoryArgs("isotest-rs", &isoTestRsDescription)
-		isoTestRs, err := github.NewRepository(ctx, "isotest-rs", &isoTestRsRepositoryArgs, pulumi.Import(pulumi.ID("isotest-rs")))
+		isoTestRsRepositoryArgs := StandardRepositoryArgs(ctx, "isotest-rs", &isoTestRsDescription)
+		isoTestRs, err := NewHolochainRepository(ctx, "isotest-rs", &isoTestRsRepositoryArgs, pulumi.Import(pulumi.ID("isotest-rs")))
 		if err != nil {
 			return err
 		}
@@ -1226,12 +1356,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "isotest-rs", isoTestRs); err != nil {
 			return err
 		}
-		isoTestRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(isoTestRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "isotest-rs-default", &isoTestRsDefaultRepositoryRulesetArgs); err != nil {
+		isoTestRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(isoTestRs, NewRulesetOptions(ctx, "isotest-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "isotest-rs-default", &isoTestRsDefaultRepositoryRulesetArgs, InRepository(ctx, "isotest-rs")); err != nil {
 			return err
 		}
-		isoTestRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(isoTestRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "isotest-rs-release", &isoTestRsReleaseRepositoryRulesetArgs); err != nil {
+		isoTestRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(isoTestRs, NewRulesetOptions(ctx, "isotest-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "isotest-rs-release", &isoTestRsReleaseRepositoryRulesetArgs, InRepository(ctx, "isotest-rs")); err != nil {
 			return err
 		}
 
@@ -1239,8 +1369,8 @@ func main() {
 		// one_err
 		//
 		oneErrDescription := "OneErr to rule them all"
-		oneErrRepositoryArgs := StandardRepositoryArgs("one_err", &oneErrDescription)
-		oneErr, err := github.NewRepository(ctx, "one_err", &oneErrRepositoryArgs, pulumi.Import(pulumi.ID("one_err")))
+		oneErrRepositoryArgs := StandardRepositoryArgs(ctx, "one_err", &oneErrDescription)
+		oneErr, err := NewHolochainRepository(ctx, "one_err", &oneErrRepositoryArgs, pulumi.Import(pulumi.ID("one_err")))
 		if err != nil {
 			return err
 		}
@@ -1250,12 +1380,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "one_err", oneErr); err != nil {
 			return err
 		}
-		oneErrDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(oneErr, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "one_err-default", &oneErrDefaultRepositoryRulesetArgs); err != nil {
+		oneErrDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(oneErr, NewRulesetOptions(ctx, "one_err"))
+		if _, err = github.NewRepositoryRuleset(ctx, "one_err-default", &oneErrDefaultRepositoryRulesetArgs, InRepository(ctx, "one_err")); err != nil {
 			return err
 		}
-		oneErrReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(oneErr, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "one_err-release", &oneErrReleaseRepositoryRulesetArgs); err != nil {
+		oneErrReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(oneErr, NewRulesetOptions(ctx, "one_err"))
+		if _, err = github.NewRepositoryRuleset(ctx, "one_err-release", &oneErrReleaseRepositoryRulesetArgs, InRepository(ctx, "one_err")); err != nil {
 			return err
 		}
 
@@ -1263,8 +1393,8 @@ func main() {
 		// bootstrap
 		//
 		bootstrapDescription := "Bootstrap nodes onto a network by allowing existing nodes to list themselves under a URL"
-		bootstrapRepositoryArgs := StandardRepositoryArgs("bootstrap", &bootstrapDescription)
-		bootstrap, err := github.NewRepository(ctx, "bootstrap", &bootstrapRepositoryArgs, pulumi.Import(pulumi.ID("bootstrap")))
+		bootstrapRepositoryArgs := StandardRepositoryArgs(ctx, "bootstrap", &bootstrapDescription)
+		bootstrap, err := NewHolochainRepository(ctx, "bootstrap", &bootstrapRepositoryArgs, pulumi.Import(pulumi.ID("bootstrap")))
 		if err != nil {
 			return err
 		}
@@ -1274,12 +1404,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "bootstrap", bootstrap); err != nil {
 			return err
 		}
-		bootstrapDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(bootstrap, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap-default", &bootstrapDefaultRepositoryRulesetArgs); err != nil {
+		bootstrapDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(bootstrap, NewRulesetOptions(ctx, "bootstrap"))
+		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap-default", &bootstrapDefaultRepositoryRulesetArgs, InRepository(ctx, "bootstrap")); err != nil {
 			return err
 		}
-		bootstrapReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(bootstrap, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap-release", &bootstrapReleaseRepositoryRulesetArgs); err != nil {
+		bootstrapReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(bootstrap, NewRulesetOptions(ctx, "bootstrap"))
+		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap-release", &bootstrapReleaseRepositoryRulesetArgs, InRepository(ctx, "bootstrap")); err != nil {
 			return err
 		}
 
@@ -1287,8 +1417,8 @@ func main() {
 		// ametrics
 		//
 		ametricsDescription := "ametrics metric abstraction helpers"
-		ametricsRepositoryArgs := StandardRepositoryArgs("ametrics", &ametricsDescription)
-		ametrics, err := github.NewRepository(ctx, "ametrics", &ametricsRepositoryArgs, pulumi.Import(pulumi.ID("ametrics")))
+		ametricsRepositoryArgs := StandardRepositoryArgs(ctx, "ametrics", &ametricsDescription)
+		ametrics, err := NewHolochainRepository(ctx, "ametrics", &ametricsRepositoryArgs, pulumi.Import(pulumi.ID("ametrics")))
 		if err != nil {
 			return err
 		}
@@ -1298,12 +1428,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "ametrics", ametrics); err != nil {
 			return err
 		}
-		ametricsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(ametrics, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "ametrics-default", &ametricsDefaultRepositoryRulesetArgs); err != nil {
+		ametricsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(ametrics, NewRulesetOptions(ctx, "ametrics"))
+		if _, err = github.NewRepositoryRuleset(ctx, "ametrics-default", &ametricsDefaultRepositoryRulesetArgs, InRepository(ctx, "ametrics")); err != nil {
 			return err
 		}
-		ametricsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(ametrics, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "ametrics-release", &ametricsReleaseRepositoryRulesetArgs); err != nil {
+		ametricsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(ametrics, NewRulesetOptions(ctx, "ametrics"))
+		if _, err = github.NewRepositoryRuleset(ctx, "ametrics-release", &ametricsReleaseRepositoryRulesetArgs, InRepository(ctx, "ametrics")); err != nil {
 			return err
 		}
 
@@ -1311,8 +1441,8 @@ func main() {
 		// contrafact-rs
 		//
 		contrafactRsDescription := "Generate test fixtures and check data properties with declarative, modular constraints"
-		contrafactRsRepositoryArgs := StandardRepositoryArgs("contrafact-rs", &contrafactRsDescription)
-		contrafactRs, err := github.NewRepository(ctx, "contrafact-rs", &contrafactRsRepositoryArgs, pulumi.Import(pulumi.ID("contrafact-rs")))
+		contrafactRsRepositoryArgs := StandardRepositoryArgs(ctx, "contrafact-rs", &contrafactRsDescription)
+		contrafactRs, err := NewHolochainRepository(ctx, "contrafact-rs", &contrafactRsRepositoryArgs, pulumi.Import(pulumi.ID("contrafact-rs")))
 		if err != nil {
 			return err
 		}
@@ -1322,12 +1452,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "contrafact-rs", contrafactRs); err != nil {
 			return err
 		}
-		contrafactRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(contrafactRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "contrafact-rs-default", &contrafactRsDefaultRepositoryRulesetArgs); err != nil {
+		contrafactRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(contrafactRs, NewRulesetOptions(ctx, "contrafact-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "contrafact-rs-default", &contrafactRsDefaultRepositoryRulesetArgs, InRepository(ctx, "contrafact-rs")); err != nil {
 			return err
 		}
-		contrafactRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(contrafactRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "contrafact-rs-release", &contrafactRsReleaseRepositoryRulesetArgs); err != nil {
+		contrafactRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(contrafactRs, NewRulesetOptions(ctx, "contrafact-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "contrafact-rs-release", &contrafactRsReleaseRepositoryRulesetArgs, InRepository(ctx, "contrafact-rs")); err != nil {
 			return err
 		}
 
@@ -1335,8 +1465,8 @@ func main() {
 		// task-motel-rs
 		//
 		taskMotelRsDescription := "An opinionated Tokio task manager"
-		taskMotelRsRepositoryArgs := StandardRepositoryArgs("task-motel-rs", &taskMotelRsDescription)
-		taskMotelRs, err := github.NewRepository(ctx, "task-motel-rs", &taskMotelRsRepositoryArgs, pulumi.Import(pulumi.ID("task-motel-rs")))
+		taskMotelRsRepositoryArgs := StandardRepositoryArgs(ctx, "task-motel-rs", &taskMotelRsDescription)
+		taskMotelRs, err := NewHolochainRepository(ctx, "task-motel-rs", &taskMotelRsRepositoryArgs, pulumi.Import(pulumi.ID("task-motel-rs")))
 		if err != nil {
 			return err
 		}
@@ -1346,12 +1476,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "task-motel-rs", taskMotelRs); err != nil {
 			return err
 		}
-		taskMotelRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(taskMotelRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "task-motel-rs-default", &taskMotelRsDefaultRepositoryRulesetArgs); err != nil {
+		taskMotelRsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(taskMotelRs, NewRulesetOptions(ctx, "task-motel-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "task-motel-rs-default", &taskMotelRsDefaultRepositoryRulesetArgs, InRepository(ctx, "task-motel-rs")); err != nil {
 			return err
 		}
-		taskMotelRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(taskMotelRs, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "task-motel-rs-release", &taskMotelRsReleaseRepositoryRulesetArgs); err != nil {
+		taskMotelRsReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(taskMotelRs, NewRulesetOptions(ctx, "task-motel-rs"))
+		if _, err = github.NewRepositoryRuleset(ctx, "task-motel-rs-release", &taskMotelRsReleaseRepositoryRulesetArgs, InRepository(ctx, "task-motel-rs")); err != nil {
 			return err
 		}
 
@@ -1359,8 +1489,8 @@ func main() {
 		// devhub-gui
 		//
 		devHubGuiDescription := "A web-based UI that works with Holochain's collection of DevHub DNAs."
-		devHubGuiRepositoryArgs := StandardRepositoryArgs("devhub-gui", &devHubGuiDescription)
-		devHubGui, err := github.NewRepository(ctx, "devhub-gui", &devHubGuiRepositoryArgs, pulumi.Import(pulumi.ID("devhub-gui")))
+		devHubGuiRepositoryArgs := StandardRepositoryArgs(ctx, "devhub-gui", &devHubGuiDescription)
+		devHubGui, err := NewHolochainRepository(ctx, "devhub-gui", &devHubGuiRepositoryArgs, pulumi.Import(pulumi.ID("devhub-gui")))
 		if err != nil {
 			return err
 		}
@@ -1370,12 +1500,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "devhub-gui", devHubGui); err != nil {
 			return err
 		}
-		devHubGuiDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(devHubGui, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "devhub-gui-default", &devHubGuiDefaultRepositoryRulesetArgs); err != nil {
+		devHubGuiDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(devHubGui, NewRulesetOptions(ctx, "devhub-gui"))
+		if _, err = github.NewRepositoryRuleset(ctx, "devhub-gui-default", &devHubGuiDefaultRepositoryRulesetArgs, InRepository(ctx, "devhub-gui")); err != nil {
 			return err
 		}
-		devHubGuiReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(devHubGui, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "devhub-gui-release", &devHubGuiReleaseRepositoryRulesetArgs); err != nil {
+		devHubGuiReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(devHubGui, NewRulesetOptions(ctx, "devhub-gui"))
+		if _, err = github.NewRepositoryRuleset(ctx, "devhub-gui-release", &devHubGuiReleaseRepositoryRulesetArgs, InRepository(ctx, "devhub-gui")); err != nil {
 			return err
 		}
 
@@ -1383,8 +1513,8 @@ func main() {
 		// app-store-gui
 		//
 		appStoreGuiDescription := "A web-based UI that works with Holochain's collection of App Store DNAs."
-		appStoreGuiRepositoryArgs := StandardRepositoryArgs("app-store-gui", &appStoreGuiDescription)
-		appStoreGui, err := github.NewRepository(ctx, "app-store-gui", &appStoreGuiRepositoryArgs, pulumi.Import(pulumi.ID("app-store-gui")))
+		appStoreGuiRepositoryArgs := StandardRepositoryArgs(ctx, "app-store-gui", &appStoreGuiDescription)
+		appStoreGui, err := NewHolochainRepository(ctx, "app-store-gui", &appStoreGuiRepositoryArgs, pulumi.Import(pulumi.ID("app-store-gui")))
 		if err != nil {
 			return err
 		}
@@ -1394,12 +1524,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "app-store-gui", appStoreGui); err != nil {
 			return err
 		}
-		appStoreGuiDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(appStoreGui, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "app-store-gui-default", &appStoreGuiDefaultRepositoryRulesetArgs); err != nil {
+		appStoreGuiDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(appStoreGui, NewRulesetOptions(ctx, "app-store-gui"))
+		if _, err = github.NewRepositoryRuleset(ctx, "app-store-gui-default", &appStoreGuiDefaultRepositoryRulesetArgs, InRepository(ctx, "app-store-gui")); err != nil {
 			return err
 		}
-		appStoreGuiReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(appStoreGui, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "app-store-gui-release", &appStoreGuiReleaseRepositoryRulesetArgs); err != nil {
+		appStoreGuiReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(appStoreGui, NewRulesetOptions(ctx, "app-store-gui"))
+		if _, err = github.NewRepositoryRuleset(ctx, "app-store-gui-release", &appStoreGuiReleaseRepositoryRulesetArgs, InRepository(ctx, "app-store-gui")); err != nil {
 			return err
 		}
 
@@ -1407,8 +1537,8 @@ func main() {
 		// bootstrap2
 		//
 		bootstrap2Description := "Holochain bootstrap peer discovery."
-		bootstrap2RepositoryArgs := StandardRepositoryArgs("bootstrap2", &bootstrap2Description)
-		bootstrap2, err := github.NewRepository(ctx, "bootstrap2", &bootstrap2RepositoryArgs, pulumi.Import(pulumi.ID("bootstrap2")))
+		bootstrap2RepositoryArgs := StandardRepositoryArgs(ctx, "bootstrap2", &bootstrap2Description)
+		bootstrap2, err := NewHolochainRepository(ctx, "bootstrap2", &bootstrap2RepositoryArgs, pulumi.Import(pulumi.ID("bootstrap2")))
 		if err != nil {
 			return err
 		}
@@ -1418,12 +1548,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "bootstrap2", bootstrap2); err != nil {
 			return err
 		}
-		bootstrap2DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(bootstrap2, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap2-default", &bootstrap2DefaultRepositoryRulesetArgs); err != nil {
+		bootstrap2DefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(bootstrap2, NewRulesetOptions(ctx, "bootstrap2"))
+		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap2-default", &bootstrap2DefaultRepositoryRulesetArgs, InRepository(ctx, "bootstrap2")); err != nil {
 			return err
 		}
-		bootstrap2ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(bootstrap2, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap2-release", &bootstrap2ReleaseRepositoryRulesetArgs); err != nil {
+		bootstrap2ReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(bootstrap2, NewRulesetOptions(ctx, "bootstrap2"))
+		if _, err = github.NewRepositoryRuleset(ctx, "bootstrap2-release", &bootstrap2ReleaseRepositoryRulesetArgs, InRepository(ctx, "bootstrap2")); err != nil {
 			return err
 		}
 
@@ -1431,8 +1561,8 @@ func main() {
 		// release-integration
 		//
 		releaseIntegrationDescription := "Integration of third-party release tools with Holochain repositories"
-		releaseIntegrationRepositoryArgs := StandardRepositoryArgs("release-integration", &releaseIntegrationDescription)
-		releaseIntegration, err := github.NewRepository(ctx, "release-integration", &releaseIntegrationRepositoryArgs)
+		releaseIntegrationRepositoryArgs := StandardRepositoryArgs(ctx, "release-integration", &releaseIntegrationDescription)
+		releaseIntegration, err := NewHolochainRepository(ctx, "release-integration", &releaseIntegrationRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1442,11 +1572,17 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "release-integration", releaseIntegration); err != nil {
 			return err
 		}
-		releaseIntegrationDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(releaseIntegration, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "release-integration-default", &releaseIntegrationDefaultRepositoryRulesetArgs); err != nil {
+		releaseIntegrationDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(releaseIntegration, NewRulesetOptions(ctx, "release-integration"))
+		if _, err = github.NewRepositoryRuleset(ctx, "release-integration-default", &releaseIntegrationDefaultRepositoryRulesetArgs, InRepository(ctx, "release-integration")); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "release-integration", releaseIntegration); err != nil {
+		if err = AddSharedFiles(ctx, "release-integration", releaseIntegration, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: releaseIntegrationDescription,
+				Ecosystems:  []Ecosystem{EcosystemRust},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -1454,8 +1590,8 @@ func main() {
 		// actions
 		//
 		actionsDescription := "Actions for common tasks in Holochain repositories"
-		actionsRepositoryArgs := StandardRepositoryArgs("actions", &actionsDescription)
-		actions, err := github.NewRepository(ctx, "actions", &actionsRepositoryArgs)
+		actionsRepositoryArgs := StandardRepositoryArgs(ctx, "actions", &actionsDescription)
+		actions, err := NewHolochainRepository(ctx, "actions", &actionsRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1465,11 +1601,11 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "actions", actions); err != nil {
 			return err
 		}
-		actionsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(actions, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "actions-default", &actionsDefaultRepositoryRulesetArgs); err != nil {
+		actionsDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(actions, NewRulesetOptions(ctx, "actions"))
+		if _, err = github.NewRepositoryRuleset(ctx, "actions-default", &actionsDefaultRepositoryRulesetArgs, InRepository(ctx, "actions")); err != nil {
 			return err
 		}
-		if err = AddGithubWorkflowsTokenSecret(ctx, conf, "actions"); err != nil {
+		if err = AddGithubToken(ctx, "actions", GithubTokenConfig{Actions: GithubTokenWorkflows}); err != nil {
 			return err
 		}
 		if _, err = github.NewRepositoryRuleset(ctx, "actions-stable-ruleset", &github.RepositoryRulesetArgs{
@@ -1497,7 +1633,7 @@ func main() {
 					BypassMode: pulumi.String("always"),
 				},
 			},
-		}); err != nil {
+		}, InRepository(ctx, "actions")); err != nil {
 			return err
 		}
 
@@ -1505,8 +1641,8 @@ func main() {
 		// Mattermost bot
 		//
 		mattermostBotDescription := "A Mattermost ChatOps bot for the Holochain project"
-		mattermostBotRepositoryArgs := StandardRepositoryArgs("hc-mattermost-bot", &mattermostBotDescription)
-		mattermostBot, err := github.NewRepository(ctx, "hc-mattermost-bot", &mattermostBotRepositoryArgs)
+		mattermostBotRepositoryArgs := StandardRepositoryArgs(ctx, "hc-mattermost-bot", &mattermostBotDescription)
+		mattermostBot, err := NewHolochainRepository(ctx, "hc-mattermost-bot", &mattermostBotRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1516,12 +1652,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-mattermost-bot", mattermostBot); err != nil {
 			return err
 		}
-		mattermostBotDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(mattermostBot, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-mattermost-bot-default", &mattermostBotDefaultRepositoryRulesetArgs); err != nil {
+		mattermostBotDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(mattermostBot, NewRulesetOptions(ctx, "hc-mattermost-bot"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-mattermost-bot-default", &mattermostBotDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-mattermost-bot")); err != nil {
 			return err
 		}
-		mattermostBotReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(mattermostBot, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-mattermost-bot-release", &mattermostBotReleaseRepositoryRulesetArgs); err != nil {
+		mattermostBotReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(mattermostBot, NewRulesetOptions(ctx, "hc-mattermost-bot"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-mattermost-bot-release", &mattermostBotReleaseRepositoryRulesetArgs, InRepository(ctx, "hc-mattermost-bot")); err != nil {
 			return err
 		}
 
@@ -1529,8 +1665,8 @@ func main() {
 		// Wind Tunnel Runner Status Dashboard
 		//
 		windTunnelRunnerStatusDashboardDescription := "A web app to view the connection status of Wind Tunnel Runner nodes."
-		windTunnelRunnerStatusDashboardRepositoryArgs := StandardRepositoryArgs("wind-tunnel-runner-status-dashboard", &windTunnelRunnerStatusDashboardDescription)
-		windTunnelRunnerStatusDashboard, err := github.NewRepository(ctx, "wind-tunnel-runner-status-dashboard", &windTunnelRunnerStatusDashboardRepositoryArgs)
+		windTunnelRunnerStatusDashboardRepositoryArgs := StandardRepositoryArgs(ctx, "wind-tunnel-runner-status-dashboard", &windTunnelRunnerStatusDashboardDescription)
+		windTunnelRunnerStatusDashboard, err := NewHolochainRepository(ctx, "wind-tunnel-runner-status-dashboard", &windTunnelRunnerStatusDashboardRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1540,14 +1676,19 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "wind-tunnel-runner-status-dashboard", windTunnelRunnerStatusDashboard); err != nil {
 			return err
 		}
-		windTunnelRunnerStatusDashboardDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(windTunnelRunnerStatusDashboard, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-runner-status-dashboard-default", &windTunnelRunnerStatusDashboardDefaultRepositoryRulesetArgs); err != nil {
+		windTunnelRunnerStatusDashboardDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(windTunnelRunnerStatusDashboard, NewRulesetOptions(ctx, "wind-tunnel-runner-status-dashboard"))
+		if _, err = github.NewRepositoryRuleset(ctx, "wind-tunnel-runner-status-dashboard-default", &windTunnelRunnerStatusDashboardDefaultRepositoryRulesetArgs, InRepository(ctx, "wind-tunnel-runner-status-dashboard")); err != nil {
 			return err
 		}
-		if err = AddPulumiAccessTokenSecret(ctx, conf, "wind-tunnel-runner-status-dashboard"); err != nil {
+		if err = AddPulumiAccessTokenSecret(ctx, "wind-tunnel-runner-status-dashboard"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "wind-tunnel-runner-status-dashboard", windTunnelRunnerStatusDashboard); err != nil {
+		if err = AddSharedFiles(ctx, "wind-tunnel-runner-status-dashboard", windTunnelRunnerStatusDashboard, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: windTunnelRunnerStatusDashboardDescription,
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -1555,8 +1696,8 @@ func main() {
 		// hc-auth-server
 		//
 		hcAuthServerDescription := "Authentication hook server to use with kitsune2-bootstrap-srv"
-		hcAuthServerRepositoryArgs := StandardRepositoryArgs("hc-auth-server", &hcAuthServerDescription)
-		hcAuthServer, err := github.NewRepository(ctx, "hc-auth-server", &hcAuthServerRepositoryArgs, pulumi.Import(pulumi.ID("hc-auth-server")))
+		hcAuthServerRepositoryArgs := StandardRepositoryArgs(ctx, "hc-auth-server", &hcAuthServerDescription)
+		hcAuthServer, err := NewHolochainRepository(ctx, "hc-auth-server", &hcAuthServerRepositoryArgs, pulumi.Import(pulumi.ID("hc-auth-server")))
 		if err != nil {
 			return err
 		}
@@ -1566,24 +1707,27 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "hc-auth-server", hcAuthServer); err != nil {
 			return err
 		}
-		hcAuthServerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcAuthServer, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-auth-server-default", &hcAuthServerDefaultRepositoryRulesetArgs); err != nil {
+		hcAuthServerDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(hcAuthServer, NewRulesetOptions(ctx, "hc-auth-server"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-auth-server-default", &hcAuthServerDefaultRepositoryRulesetArgs, InRepository(ctx, "hc-auth-server")); err != nil {
 			return err
 		}
-		hcAuthServerReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcAuthServer, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "hc-auth-server-release", &hcAuthServerReleaseRepositoryRulesetArgs); err != nil {
+		hcAuthServerReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(hcAuthServer, NewRulesetOptions(ctx, "hc-auth-server"))
+		if _, err = github.NewRepositoryRuleset(ctx, "hc-auth-server-release", &hcAuthServerReleaseRepositoryRulesetArgs, InRepository(ctx, "hc-auth-server")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "hc-auth-server", hcAuthServer); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "hc-auth-server", hcAuthServer); err != nil {
 			return err
 		}
-		if err = AddContributingGuide(ctx, "hc-auth-server", hcAuthServer); err != nil {
-			return err
-		}
-		if err = AddCodeOwners(ctx, "hc-auth-server", hcAuthServer); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "hc-auth-server", hcAuthServer, DependabotConfig{EnableRust: true}); err != nil {
+		if err = AddSharedFiles(ctx, "hc-auth-server", hcAuthServer, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   hcAuthServerDescription,
+				Ecosystems:    []Ecosystem{EcosystemRust},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+			Dependabot:        &DependabotConfig{EnableRust: true},
+		}); err != nil {
 			return err
 		}
 
@@ -1591,8 +1735,8 @@ func main() {
 		// peerkit
 		//
 		peerkitRepositoryDescription := "A TypeScript framework for providing P2P data synchronization"
-		peerkitRepositoryArgs := StandardRepositoryArgs("peerkit", &peerkitRepositoryDescription)
-		peerkit, err := github.NewRepository(ctx, "peerkit", &peerkitRepositoryArgs, pulumi.Import(pulumi.ID("peerkit")))
+		peerkitRepositoryArgs := StandardRepositoryArgs(ctx, "peerkit", &peerkitRepositoryDescription)
+		peerkit, err := NewHolochainRepository(ctx, "peerkit", &peerkitRepositoryArgs, pulumi.Import(pulumi.ID("peerkit")))
 		if err != nil {
 			return err
 		}
@@ -1602,28 +1746,33 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "peerkit", peerkit); err != nil {
 			return err
 		}
-		peerkitDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(peerkit, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-default", &peerkitDefaultRepositoryRulesetArgs); err != nil {
+		peerkitDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(peerkit, NewRulesetOptions(ctx, "peerkit"))
+		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-default", &peerkitDefaultRepositoryRulesetArgs, InRepository(ctx, "peerkit")); err != nil {
 			return err
 		}
-		peerkitReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(peerkit, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-release", &peerkitReleaseRepositoryRulesetArgs); err != nil {
+		peerkitReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(peerkit, NewRulesetOptions(ctx, "peerkit"))
+		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-release", &peerkitReleaseRepositoryRulesetArgs, InRepository(ctx, "peerkit")); err != nil {
 			return err
 		}
 		_, err = github.NewRepositoryPages(ctx, "peerkit-pages", &github.RepositoryPagesArgs{
 			Repository: peerkit.Name,
 			BuildType:  pulumi.String("workflow"),
-		})
+		}, InRepository(ctx, "peerkit"))
 		if err != nil {
 			return err
 		}
-		if err = AddNpmReleaseSupport(ctx, conf, "peerkit", peerkit); err != nil {
-			return err
-		}
-		if err = AddContributingGuide(ctx, "peerkit", peerkit); err != nil {
+		if err = AddNpmReleaseSupport(ctx, "peerkit", peerkit); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "peerkit", peerkit); err != nil {
+		if err = AddSharedFiles(ctx, "peerkit", peerkit, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   peerkitRepositoryDescription,
+				Ecosystems:    []Ecosystem{EcosystemNpm},
+				ReleaseScheme: ReleaseSchemeNpm,
+			},
+			ContributingGuide: true,
+			CodeOwners:        &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -1631,8 +1780,8 @@ func main() {
 		// peerkit bootstrap relay
 		//
 		peerkitBootstrapRelayRepositoryDescription := "Deployable Peerkit bootstrap/relay node (DigitalOcean droplet) for app and Wind Tunnel testing."
-		peerkitBootstrapRelayRepositoryArgs := StandardRepositoryArgs("peerkit-bootstrap-relay", &peerkitBootstrapRelayRepositoryDescription)
-		peerkitBootstrapRelay, err := github.NewRepository(ctx, "peerkit-bootstrap-relay", &peerkitBootstrapRelayRepositoryArgs, pulumi.Import(pulumi.ID("peerkit-bootstrap-relay")))
+		peerkitBootstrapRelayRepositoryArgs := StandardRepositoryArgs(ctx, "peerkit-bootstrap-relay", &peerkitBootstrapRelayRepositoryDescription)
+		peerkitBootstrapRelay, err := NewHolochainRepository(ctx, "peerkit-bootstrap-relay", &peerkitBootstrapRelayRepositoryArgs, pulumi.Import(pulumi.ID("peerkit-bootstrap-relay")))
 		if err != nil {
 			return err
 		}
@@ -1642,12 +1791,12 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "peerkit-bootstrap-relay", peerkitBootstrapRelay); err != nil {
 			return err
 		}
-		peerkitBootstrapRelayDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(peerkitBootstrapRelay, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-bootstrap-relay-default", &peerkitBootstrapRelayDefaultRepositoryRulesetArgs); err != nil {
+		peerkitBootstrapRelayDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(peerkitBootstrapRelay, NewRulesetOptions(ctx, "peerkit-bootstrap-relay"))
+		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-bootstrap-relay-default", &peerkitBootstrapRelayDefaultRepositoryRulesetArgs, InRepository(ctx, "peerkit-bootstrap-relay")); err != nil {
 			return err
 		}
-		peerkitBootstrapRelayReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(peerkitBootstrapRelay, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-bootstrap-relay-release", &peerkitBootstrapRelayReleaseRepositoryRulesetArgs); err != nil {
+		peerkitBootstrapRelayReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(peerkitBootstrapRelay, NewRulesetOptions(ctx, "peerkit-bootstrap-relay"))
+		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-bootstrap-relay-release", &peerkitBootstrapRelayReleaseRepositoryRulesetArgs, InRepository(ctx, "peerkit-bootstrap-relay")); err != nil {
 			return err
 		}
 
@@ -1655,8 +1804,8 @@ func main() {
 		// peerkit-video-chat
 		//
 		peerkitVCRepositoryDescription := "A video chat app built with Peerkit"
-		peerkitVCRepositoryArgs := StandardRepositoryArgs("peerkit-video-chat", &peerkitVCRepositoryDescription)
-		peerkitVC, err := github.NewRepository(ctx, "peerkit-video-chat", &peerkitVCRepositoryArgs)
+		peerkitVCRepositoryArgs := StandardRepositoryArgs(ctx, "peerkit-video-chat", &peerkitVCRepositoryDescription)
+		peerkitVC, err := NewHolochainRepository(ctx, "peerkit-video-chat", &peerkitVCRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1666,18 +1815,24 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "peerkit-video-chat", peerkitVC); err != nil {
 			return err
 		}
-		peerkitVCDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(peerkitVC, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-video-chat-default", &peerkitVCDefaultRepositoryRulesetArgs); err != nil {
+		peerkitVCDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(peerkitVC, NewRulesetOptions(ctx, "peerkit-video-chat"))
+		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-video-chat-default", &peerkitVCDefaultRepositoryRulesetArgs, InRepository(ctx, "peerkit-video-chat")); err != nil {
 			return err
 		}
-		peerkitVCReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(peerkitVC, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-video-chat-release", &peerkitVCReleaseRepositoryRulesetArgs); err != nil {
+		peerkitVCReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(peerkitVC, NewRulesetOptions(ctx, "peerkit-video-chat"))
+		if _, err = github.NewRepositoryRuleset(ctx, "peerkit-video-chat-release", &peerkitVCReleaseRepositoryRulesetArgs, InRepository(ctx, "peerkit-video-chat")); err != nil {
 			return err
 		}
 		if err = AddOutsideCollaborator(ctx, "peerkit-video-chat", peerkitVC, "synchwire"); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "peerkit-video-chat", peerkitVC); err != nil {
+		if err = AddSharedFiles(ctx, "peerkit-video-chat", peerkitVC, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description: peerkitVCRepositoryDescription,
+				Ecosystems:  []Ecosystem{EcosystemNpm},
+			},
+			CodeOwners: &CodeOwnersConfig{},
+		}); err != nil {
 			return err
 		}
 
@@ -1685,8 +1840,8 @@ func main() {
 		// sodoken
 		//
 		sodokenRepositoryDescription := "Libsodium wrapper providing tokio safe memory secure api access."
-		sodokenRepositoryArgs := StandardRepositoryArgs("sodoken", &sodokenRepositoryDescription)
-		sodoken, err := github.NewRepository(ctx, "sodoken", &sodokenRepositoryArgs, pulumi.Import(pulumi.ID("sodoken")))
+		sodokenRepositoryArgs := StandardRepositoryArgs(ctx, "sodoken", &sodokenRepositoryDescription)
+		sodoken, err := NewHolochainRepository(ctx, "sodoken", &sodokenRepositoryArgs, pulumi.Import(pulumi.ID("sodoken")))
 		if err != nil {
 			return err
 		}
@@ -1696,22 +1851,27 @@ func main() {
 		if err = StandardRepositoryAccess(ctx, "sodoken", sodoken); err != nil {
 			return err
 		}
-		sodokenDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(sodoken, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "sodoken-default", &sodokenDefaultRepositoryRulesetArgs); err != nil {
+		sodokenDefaultRepositoryRulesetArgs := DefaultRepositoryRulesetArgs(sodoken, NewRulesetOptions(ctx, "sodoken"))
+		if _, err = github.NewRepositoryRuleset(ctx, "sodoken-default", &sodokenDefaultRepositoryRulesetArgs, InRepository(ctx, "sodoken")); err != nil {
 			return err
 		}
-		sodokenReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(sodoken, NewRulesetOptions())
-		if _, err = github.NewRepositoryRuleset(ctx, "sodoken-release", &sodokenReleaseRepositoryRulesetArgs); err != nil {
+		sodokenReleaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(sodoken, NewRulesetOptions(ctx, "sodoken"))
+		if _, err = github.NewRepositoryRuleset(ctx, "sodoken-release", &sodokenReleaseRepositoryRulesetArgs, InRepository(ctx, "sodoken")); err != nil {
 			return err
 		}
-		if err = AddReleaseIntegrationSupport(ctx, conf, "sodoken", sodoken); err != nil {
+		if err = AddReleaseIntegrationSupport(ctx, "sodoken", sodoken); err != nil {
 			return err
 		}
-		if err = AddCodeOwners(ctx, "sodoken", sodoken); err != nil {
-			return err
-		}
-		if err = AddDependabotYml(ctx, "sodoken", sodoken, DependabotConfig{
-			EnableRust: true,
+		if err = AddSharedFiles(ctx, "sodoken", sodoken, SharedFilesConfig{
+			Repository: RepositoryMetadata{
+				Description:   sodokenRepositoryDescription,
+				Ecosystems:    []Ecosystem{EcosystemRust},
+				ReleaseScheme: ReleaseSchemeReleaseIntegration,
+			},
+			CodeOwners: &CodeOwnersConfig{},
+			Dependabot: &DependabotConfig{
+				EnableRust: true,
+			},
 		}); err != nil {
 			return err
 		}
@@ -1720,9 +1880,10 @@ func main() {
 		// wind-tunnel-peerkit-bootstrap-relay
 		//
 		windTunnelPeerkitBootstrapRelayDescription := "Deployable Peerkit bootstrap/relay node for Wind Tunnel testing (fork of holochain/peerkit-bootstrap-relay)."
-		windTunnelPeerkitBootstrapRelayRepositoryArgs := StandardRepositoryArgs("wind-tunnel-peerkit-bootstrap-relay", &windTunnelPeerkitBootstrapRelayDescription)
-		windTunnelPeerkitBootstrapRelayRepositoryArgs.Visibility = pulumi.String("private")
-		windTunnelPeerkitBootstrapRelay, err := github.NewRepository(ctx, "wind-tunnel-peerkit-bootstrap-relay", &windTunnelPeerkitBootstrapRelayRepositoryArgs)
+		windTunnelPeerkitBootstrapRelayRepositoryArgs := StandardRepositoryArgs(ctx, "wind-tunnel-peerkit-bootstrap-relay", &windTunnelPeerkitBootstrapRelayDescription,
+			Intentional("Visibility", pulumi.String("private"), "Private fork of holochain/peerkit-bootstrap-relay for deployment to Wind Tunnel"),
+		)
+		windTunnelPeerkitBootstrapRelay, err := NewHolochainRepository(ctx, "wind-tunnel-peerkit-bootstrap-relay", &windTunnelPeerkitBootstrapRelayRepositoryArgs)
 		if err != nil {
 			return err
 		}
@@ -1733,11 +1894,89 @@ func main() {
 			return err
 		}
 
+		if err = SyncRepositoryLabels(ctx); err != nil {
+			return err
+		}
+		if err = SyncGithubAppInstallation(ctx); err != nil {
+			return err
+		}
+		if err = SyncOrganizationOidcSubjectClaims(ctx); err != nil {
+			return err
+		}
+		if err = SyncActionsPermissions(ctx); err != nil {
+			return err
+		}
+		if err = SyncRunnerGroups(ctx); err != nil {
+			return err
+		}
+
+		ExportSharedFilesDriftReport(ctx)
+
+		if err = ExportUnmanagedRepositoriesReport(ctx); err != nil {
+			return err
+		}
+
+		ExportRepositoryOverridesReport(ctx)
+		ExportSecretInventory(ctx)
+		ExportOidcSubjectClaims(ctx)
+
 		return nil
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == PreflightSecretsCommand {
+		if err := runPreflightSecrets(os.Args[2:], declare); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == UnusedSecretsCommand {
+		if err := runUnusedSecrets(os.Args[2:], declare); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == SecretInventoryCommand {
+		if err := runSecretInventory(os.Args[2:], declare); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == RevokeSecretCommand {
+		if err := runRevokeSecret(os.Args[2:], declare); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		if err := validateOrganization(ctx, declare); err != nil {
+			return err
+		}
+		if err := declare(ctx); err != nil {
+			return err
+		}
+
+		return stateFor(ctx).validationErrors.err()
 	})
 }
 
-func StandardRepositoryArgs(name string, description *string) github.RepositoryArgs {
+func StandardRepositoryArgs(ctx *pulumi.Context, name string, description *string, overrides ...RepositoryOverride) github.RepositoryArgs {
+	args := standardRepositoryArgs(name, description)
+	applyRepositoryOverrides(ctx, name, &args, overrides)
+	applyRepositoryLifecycle(ctx, name, &args, description)
+	state := stateFor(ctx)
+	state.managedRepositories = append(state.managedRepositories, name)
+
+	return args
+}
+
+// standardRepositoryArgs are the settings that every repository starts from, which are also the
+// baseline that scaffold-import compares existing repositories with.
+func standardRepositoryArgs(name string, description *string) github.RepositoryArgs {
 	args := github.RepositoryArgs{
 		Name:                pulumi.String(name),
 		Description:         nil,
@@ -1761,22 +2000,36 @@ func StandardRepositoryArgs(name string, description *string) github.RepositoryA
 	return args
 }
 
+// TeamAccess is the permission that a team has on a repository.
+type TeamAccess struct {
+	Team       string
+	Permission string
+}
+
+// standardTeamAccess is the access that StandardRepositoryAccess gives to every repository.
+var standardTeamAccess = []TeamAccess{
+	{Team: "core-dev", Permission: "admin"},
+	{Team: "holochain-devs", Permission: "maintain"},
+}
+
 func StandardRepositoryAccess(ctx *pulumi.Context, name string, repository *github.Repository) error {
-	_, err := github.NewTeamRepository(ctx, fmt.Sprintf("%s-collaborator-core-dev", name), &github.TeamRepositoryArgs{
-		Repository: repository.Name,
-		Permission: pulumi.String("admin"),
-		TeamId:     pulumi.String("core-dev"),
-	})
-	if err != nil {
-		return err
+	for _, access := range standardTeamAccess {
+		permission := access.Permission
+		// Archived repositories are read-only, core-dev keeps admin access so that they can be unarchived.
+		if isRetired(name) && permission != "admin" {
+			permission = "pull"
+		}
+		_, err := github.NewTeamRepository(ctx, fmt.Sprintf("%s-collaborator-%s", name, access.Team), &github.TeamRepositoryArgs{
+			Repository: repository.Name,
+			Permission: pulumi.String(permission),
+			TeamId:     pulumi.String(access.Team),
+		}, InRepository(ctx, name))
+		if err != nil {
+			return err
+		}
 	}
-	_, err = github.NewTeamRepository(ctx, fmt.Sprintf("%s-collaborator-holochain-devs", name), &github.TeamRepositoryArgs{
-		Repository: repository.Name,
-		Permission: pulumi.String("maintain"),
-		TeamId:     pulumi.String("holochain-devs"),
-	})
 
-	return err
+	return nil
 }
 
 func RequireMainAsDefaultBranch(ctx *pulumi.Context, name string, repository *github.Repository) error {
@@ -1784,7 +2037,7 @@ func RequireMainAsDefaultBranch(ctx *pulumi.Context, name string, repository *gi
 		Repository: repository.Name,
 		Branch:     pulumi.String("main"),
 		Rename:     pulumi.Bool(false),
-	})
+	}, InRepository(ctx, name))
 
 	return err
 }
@@ -1794,24 +2047,28 @@ func MigrateDefaultBranchToMain(ctx *pulumi.Context, name string, repository *gi
 		Repository: repository.Name,
 		Branch:     pulumi.String("main"),
 		Rename:     pulumi.Bool(true),
-	})
+	}, InRepository(ctx, name))
 
 	return err
 }
 
 type RulesetOptions struct {
+	// ctx and repository are where conflicting options are reported.
+	ctx                 *pulumi.Context
+	repository          string
 	extraStatusChecks   []github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs
 	withoutStatusChecks bool
 	noLinearHistory     bool
 }
 
-func NewRulesetOptions() RulesetOptions {
-	return RulesetOptions{}
+func NewRulesetOptions(ctx *pulumi.Context, repository string) RulesetOptions {
+	return RulesetOptions{ctx: ctx, repository: repository}
 }
 
 func (options RulesetOptions) withExtraStatusChecks(extraStatusChecks []github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs) RulesetOptions {
 	if options.withoutStatusChecks {
-		panic("withExtraStatusChecks() cannot be called if noStatusChecks() has already been called.")
+		invalid(options.ctx, options.repository, "withExtraStatusChecks() cannot be called if noStatusChecks() has already been called")
+		return options
 	}
 	options.extraStatusChecks = extraStatusChecks
 	return options
@@ -1824,7 +2081,8 @@ func (options RulesetOptions) noLinearHistoryRequired() RulesetOptions {
 
 func (options RulesetOptions) noStatusChecks() RulesetOptions {
 	if options.extraStatusChecks != nil {
-		panic("noStatusChecks() cannot be called if extraStatusChecks() has already been called.")
+		invalid(options.ctx, options.repository, "noStatusChecks() cannot be called if withExtraStatusChecks() has already been called")
+		return options
 	}
 	options.withoutStatusChecks = true
 	return options
@@ -1834,7 +2092,7 @@ func DefaultRepositoryRulesetArgs(repository *github.Repository, options Ruleset
 	requiredChecks := github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArray{
 		github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs{
 			// Each repository should define a single job that checks all the required checks passed.
-			Context: pulumi.String("ci_pass"),
+			Context: pulumi.String(CiPassCheck),
 		},
 	}
 	if options.extraStatusChecks != nil {
@@ -1889,7 +2147,7 @@ func ReleaseRepositoryRulesetArgs(repository *github.Repository, options Ruleset
 	requiredChecks := github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArray{
 		github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs{
 			// Each repository should define a single job that checks all the required checks passed.
-			Context: pulumi.String("ci_pass"),
+			Context: pulumi.String(CiPassCheck),
 		},
 	}
 	if options.extraStatusChecks != nil {
@@ -1959,176 +2217,153 @@ func ReleaseRepositoryRulesetArgs(repository *github.Repository, options Ruleset
 	}
 }
 
-func AddGithubUserTokenSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	// A GITHUB_TOKEN with standard repository access to be used on most repositories.
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-github-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HRA2_GITHUB_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hra2GithubUserToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-func AddGithubAdminTokenSecretForDependabot(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	// A GITHUB_TOKEN with standard repository access to be used on most repositories.
-	_, err := github.NewDependabotSecret(ctx, fmt.Sprintf("%s-dependabot-github-token", repository), &github.DependabotSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HRA2_GITHUB_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hra2GithubAdminToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-func AddGithubWorkflowsTokenSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	// A GITHUB_TOKEN with standard repository access + permissions to edit and control workflows.
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-github-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HRA2_GITHUB_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hra2GithubWorkflowsToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+func AddCratesIoTokenSecret(ctx *pulumi.Context, name string, repository *github.Repository) error {
+	if isRetired(name) {
+		return nil
+	}
 
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   name,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-crates-io-token", name),
+		SecretName:   "HRA2_CRATES_IO_TOKEN",
+		Key:          "hra2CratesIoToken",
+	}, pulumi.DependsOn([]pulumi.Resource{repository}))
 }
 
-func AddGithubAdminTokenSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	// A GITHUB_TOKEN with more access rights to larger scopes.
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-github-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HRA2_GITHUB_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hra2GithubAdminToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-func AddCratesIoTokenSecret(ctx *pulumi.Context, cfg *config.Config, name string, repository *github.Repository) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-crates-io-token", name), &github.ActionsSecretArgs{
-		Repository: repository.Name,
-		SecretName: pulumi.String("HRA2_CRATES_IO_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hra2CratesIoToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
+func AddPulumiAccessTokenSecret(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
+	}
 
-func AddPulumiAccessTokenSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-pulumi-access-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HRA2_PULUMI_ACCESS_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hra2PulumiAccessToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err := deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-pulumi-access-token", repository),
+		SecretName:   "HRA2_PULUMI_ACCESS_TOKEN",
+		Key:          "hra2PulumiAccessToken",
+	})
 
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewDependabotSecret(ctx, fmt.Sprintf("%s-dependabot-pulumi-access-token", repository), &github.DependabotSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HRA2_PULUMI_ACCESS_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hra2PulumiAccessToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeDependabot,
+		ResourceName: fmt.Sprintf("%s-dependabot-pulumi-access-token", repository),
+		SecretName:   "HRA2_PULUMI_ACCESS_TOKEN",
+		Key:          "hra2PulumiAccessToken",
+	})
 }
 
-func AddNomadAccessTokenSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-nomad-access-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("NOMAD_ACCESS_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("nomadAccessToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+func AddNomadAccessTokenSecret(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
+	}
 
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-nomad-access-token", repository),
+		SecretName:   "NOMAD_ACCESS_TOKEN",
+		Namespace:    "wind-tunnel",
+		Key:          "nomadAccessToken",
+	})
 }
 
-func AddTailscaleOAuthSecrets(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-tailscale-oauth-client-id", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("TS_OAUTH_CLIENT_ID"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("tailscaleOAuthClientId"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+func AddTailscaleOAuthSecrets(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
+	}
+
+	err := deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-tailscale-oauth-client-id", repository),
+		SecretName:   "TS_OAUTH_CLIENT_ID",
+		Key:          "tailscaleOAuthClientId",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-tailscale-oauth-secret", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("TS_OAUTH_SECRET"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("tailscaleOAuthSecret"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-tailscale-oauth-secret", repository),
+		SecretName:   "TS_OAUTH_SECRET",
+		Key:          "tailscaleOAuthSecret",
+	})
 }
 
-func AddAppleAppSigningSecrets(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-apple-dev-identity", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("APPLE_DEV_IDENTITY"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("appleDevIdentity"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+func AddAppleAppSigningSecrets(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
+	}
+
+	err := deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-apple-dev-identity", repository),
+		SecretName:   "APPLE_DEV_IDENTITY",
+		Key:          "appleDevIdentity",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-apple-id-email", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("APPLE_ID_EMAIL"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("appleIdEmail"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-apple-id-email", repository),
+		SecretName:   "APPLE_ID_EMAIL",
+		Key:          "appleIdEmail",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-apple-id-password", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("APPLE_ID_PASSWORD"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("appleIdPassword"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-apple-id-password", repository),
+		SecretName:   "APPLE_ID_PASSWORD",
+		Key:          "appleIdPassword",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-apple-team-id", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("APPLE_TEAM_ID"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("appleTeamId"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-apple-team-id", repository),
+		SecretName:   "APPLE_TEAM_ID",
+		Key:          "appleTeamId",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-apple-certificate", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("APPLE_CERTIFICATE"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("appleCertificate"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-apple-certificate", repository),
+		SecretName:   "APPLE_CERTIFICATE",
+		Key:          "appleCertificate",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-apple-certificate-password", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("APPLE_CERTIFICATE_PASSWORD"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("appleCertificatePassword"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-apple-certificate-password", repository),
+		SecretName:   "APPLE_CERTIFICATE_PASSWORD",
+		Key:          "appleCertificatePassword",
+	})
 	if err != nil {
 		return err
 	}
@@ -2136,424 +2371,208 @@ func AddAppleAppSigningSecrets(ctx *pulumi.Context, cfg *config.Config, reposito
 	return err
 }
 
-func AddWindowsCodeSigningCertificates(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-azure-key-vault-uri", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("AZURE_KEY_VAULT_URI"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("azureKeyVaultUri"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+func AddWindowsCodeSigningCertificates(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
+	}
+
+	err := deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-azure-key-vault-uri", repository),
+		SecretName:   "AZURE_KEY_VAULT_URI",
+		Key:          "azureKeyVaultUri",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-azure-cert-name", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("AZURE_CERT_NAME"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("azureCertName"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-azure-cert-name", repository),
+		SecretName:   "AZURE_CERT_NAME",
+		Key:          "azureCertName",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-azure-tenant-id", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("AZURE_TENANT_ID"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("azureTenantId"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-azure-tenant-id", repository),
+		SecretName:   "AZURE_TENANT_ID",
+		Key:          "azureTenantId",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-azure-client-id", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("AZURE_CLIENT_ID"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("azureClientId"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+	err = deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-azure-client-id", repository),
+		SecretName:   "AZURE_CLIENT_ID",
+		Key:          "azureClientId",
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-azure-client-secret", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("AZURE_CLIENT_SECRET"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("azureClientSecret"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-azure-client-secret", repository),
+		SecretName:   "AZURE_CLIENT_SECRET",
+		Key:          "azureClientSecret",
+	})
 }
 
-func AddCachixAuthTokenSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-cachix-auth-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("CACHIX_AUTH_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("cachixAuthToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
+func AddCachixAuthTokenSecret(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
+	}
 
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-cachix-auth-token", repository),
+		SecretName:   "CACHIX_AUTH_TOKEN",
+		Key:          "cachixAuthToken",
+	})
 }
 
 func AddReleaseIntegrationLabel(ctx *pulumi.Context, name string, repository *github.Repository) error {
-	if _, err := github.NewIssueLabel(ctx, fmt.Sprintf("%s-hra-release-label", name), &github.IssueLabelArgs{
-		Repository: repository.Name,
-		// Must match what the holochain_release_integration CLI looks for.
-		Name: pulumi.String("hra-release"),
-		// Golden Fizz
-		Color: pulumi.String("E8F723"),
-	}); err != nil {
-		return err
-	}
-
-	return nil
+	return AddRepositoryLabels(ctx, name, repository, HraReleaseLabel)
 }
 
-func AddReleaseIntegrationSupport(ctx *pulumi.Context, cfg *config.Config, name string, repository *github.Repository) error {
+func AddReleaseIntegrationSupport(ctx *pulumi.Context, name string, repository *github.Repository) error {
 	if err := AddReleaseIntegrationLabel(ctx, name, repository); err != nil {
 		return err
 	}
-	if err := AddGithubUserTokenSecret(ctx, cfg, name); err != nil {
+	if err := AddGithubToken(ctx, name, GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 		return err
 	}
-	if err := AddCratesIoTokenSecret(ctx, cfg, name, repository); err != nil {
+	if err := AddCratesIoTokenSecret(ctx, name, repository); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func AddNpmReleaseSupport(ctx *pulumi.Context, cfg *config.Config, name string, repository *github.Repository) error {
+func AddNpmReleaseSupport(ctx *pulumi.Context, name string, repository *github.Repository) error {
 	if err := AddReleaseIntegrationLabel(ctx, name, repository); err != nil {
 		return err
 	}
-	if err := AddGithubUserTokenSecret(ctx, cfg, name); err != nil {
+	if err := AddGithubToken(ctx, name, GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func AddGoReleaseSupport(ctx *pulumi.Context, cfg *config.Config, name string, repository *github.Repository) error {
+func AddGoReleaseSupport(ctx *pulumi.Context, name string, repository *github.Repository) error {
 	if err := AddReleaseIntegrationLabel(ctx, name, repository); err != nil {
 		return err
 	}
-	if err := AddGithubUserTokenSecret(ctx, cfg, name); err != nil {
+	if err := AddGithubToken(ctx, name, GithubTokenConfig{Actions: GithubTokenUser}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func AddHetznerHolochainInfraBucketsSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-hetzner-holochain-infra-buckets-access", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HETZNER_HOLOCHAIN_INFRA_BUCKETS_ACCESS"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hetznerHolochainInfraBucketsAccess"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-	if err != nil {
-		return err
-	}
-	_, err = github.NewActionsSecret(ctx, fmt.Sprintf("%s-hetzner-holochain-infra-buckets-secret", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HETZNER_HOLOCHAIN_INFRA_BUCKETS_SECRET"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("hetznerHolochainInfraBucketsSecret"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-func AddClaudeCodeOauthTokenSecret(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-claude-code-oauth-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("CLAUDE_CODE_OAUTH_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("claudeCodeOauthToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-func AddThreefoldTfChainWalletMnemonic(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-threefold-tfchain-wallet-mnemonic", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("THREEFOLD_TFCHAIN_WALLET_MNEMONIC"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("threefoldTfChainWalletMnemonic"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-func AddThreefoldHubApiToken(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-threefold-hub-api-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("THREEFOLD_HUB_API_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("threefoldHubApiToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-func AddHolochainNotifierMattermostBotPersonalAccessToken(ctx *pulumi.Context, cfg *config.Config, repository string) error {
-	_, err := github.NewActionsSecret(ctx, fmt.Sprintf("%s-holochain-notifier-mattermost-bot-personal-access-token", repository), &github.ActionsSecretArgs{
-		Repository: pulumi.String(repository),
-		SecretName: pulumi.String("HOLOCHAIN_NOTIFIER_MATTERMOST_BOT_PERSONAL_ACCESS_TOKEN"),
-		// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
-		Value: cfg.RequireSecret("holochainNotifierMattermostBotPersonalAccessToken"),
-	}, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}))
-
-	return err
-}
-
-// RepositoryLabel represents a standard label that can be applied to repositories.
-type RepositoryLabel string
-
-const (
-	// ShouldBackport05 indicates changes that should be backported to the 0.5 release branch
-	ShouldBackport05 RepositoryLabel = "ShouldBackport/0.5"
-	// ShouldBackport06 indicates changes that should be backported to the 0.6 release branch
-	ShouldBackport06 RepositoryLabel = "ShouldBackport/0.6"
-	// ShouldBackport07 indicates changes that should be backported to the 0.7 release branch
-	ShouldBackport07 RepositoryLabel = "ShouldBackport/0.7"
-)
-
-// labelConfig holds the display name and color for a label.
-type labelConfig struct {
-	name        string
-	description string
-	color       string
-}
-
-// getLabelConfig maps a RepositoryLabel to its configuration (name and color).
-func getLabelConfig(label RepositoryLabel) labelConfig {
-	switch label {
-	case ShouldBackport07:
-		return labelConfig{
-			name:        "ShouldBackport07",
-			description: "Should Backport07",
-			color:       "5681D6", // Blue
-		}
-	case ShouldBackport06:
-		return labelConfig{
-			name:        "ShouldBackport/0.6",
-			description: "Post-merge into the default branch; should be backported to the release branch for version 0.6.x",
-			color:       "0E8A16", // Green
-		}
-	case ShouldBackport05:
-		return labelConfig{
-			name:        "ShouldBackport/0.5",
-			description: "Post-merge into the default branch; should be backported to the release branch for version 0.5.x",
-			color:       "0E8A16", // Green
-		}
-	default:
-		// Error, must define all labels here.
-		panic(fmt.Sprintf("Unknown RepositoryLabel: %s", label))
-	}
-}
-
-// AddRepositoryLabels creates the specified labels on a repository with consistent
-// name and color configuration.
-func AddRepositoryLabels(ctx *pulumi.Context, name string, repository *github.Repository, labels ...RepositoryLabel) error {
-	for _, label := range labels {
-		labelConfig := getLabelConfig(label)
-
-		// Create a unique resource ID by combining repo name and label name
-		resourceId := fmt.Sprintf("%s-%s-label", name, labelConfig.name)
-
-		if _, err := github.NewIssueLabel(ctx, resourceId, &github.IssueLabelArgs{
-			Repository:  repository.Name,
-			Name:        pulumi.String(labelConfig.name),
-			Description: pulumi.String(labelConfig.description),
-			Color:       pulumi.String(labelConfig.color),
-		}); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// AddHolochainBackportLabels adds standard backport labels to a repository.
-func AddHolochainBackportLabels(ctx *pulumi.Context, name string, repository *github.Repository) error {
-	return AddRepositoryLabels(ctx, name, repository, ShouldBackport05, ShouldBackport06)
-}
-
-// AddContributingGuide adds the shared CONTRIBUTING.md and AI_POLICY.md files to the
-// repository via a pull request against the `main` branch if the content has changed
-// since the last deployment.
-func AddContributingGuide(ctx *pulumi.Context, name string, repository *github.Repository) error {
-	content := strings.ReplaceAll(contributingMdContent, "{{REPO_NAME}}", name)
-	aiPolicyContent := strings.ReplaceAll(aiPolicyMdContent, "{{REPO_NAME}}", name)
-	contentHash := pulumi.String(fmt.Sprintf("%x", sha256.Sum256([]byte(content+aiPolicyContent))))
-
-	baseBranch := pulumi.String("main")
-
-	// The branch and file are disposed of by merging the PR, so Pulumi must not delete them when the
-	// content hash triggers a replacement: the branch name is reused, so deleting the old resources
-	// would remove the branch behind the new PR, or fail on a stale blob SHA.
-	branch, err := github.NewBranch(ctx, fmt.Sprintf("%s-contributing-md-branch", name), &github.BranchArgs{
-		Repository:   repository.Name,
-		Branch:       pulumi.String("chore/update-contributing-guide"),
-		SourceBranch: baseBranch,
-	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true))
-	if err != nil {
-		return err
+func AddHetznerHolochainInfraBucketsSecret(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
 	}
 
-	file, err := github.NewRepositoryFile(ctx, fmt.Sprintf("%s-contributing-md", name), &github.RepositoryFileArgs{
-		Repository:        repository.Name,
-		Branch:            branch.Branch,
-		File:              pulumi.String("CONTRIBUTING.md"),
-		Content:           pulumi.String(content),
-		CommitMessage:     pulumi.String("chore: update the CONTRIBUTING.md with shared content"),
-		CommitAuthor:      pulumi.String("Holochain Repository Automation"),
-		CommitEmail:       pulumi.String("hra@holochain.org"),
-		OverwriteOnCreate: pulumi.Bool(true),
-	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true))
+	err := deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-hetzner-holochain-infra-buckets-access", repository),
+		SecretName:   "HETZNER_HOLOCHAIN_INFRA_BUCKETS_ACCESS",
+		Key:          "hetznerHolochainInfraBucketsAccess",
+	})
 	if err != nil {
 		return err
 	}
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-hetzner-holochain-infra-buckets-secret", repository),
+		SecretName:   "HETZNER_HOLOCHAIN_INFRA_BUCKETS_SECRET",
+		Key:          "hetznerHolochainInfraBucketsSecret",
+	})
+}
 
-	// Committed to the same branch as CONTRIBUTING.md; DependsOn serialises the
-	// two commits so they don't race on the branch head. Retained on delete for
-	// the same reason as the branch and CONTRIBUTING.md above.
-	aiPolicyFile, err := github.NewRepositoryFile(ctx, fmt.Sprintf("%s-ai-policy-md", name), &github.RepositoryFileArgs{
-		Repository:        repository.Name,
-		Branch:            branch.Branch,
-		File:              pulumi.String("AI_POLICY.md"),
-		Content:           pulumi.String(aiPolicyContent),
-		CommitMessage:     pulumi.String("chore: update the AI_POLICY.md with shared content"),
-		CommitAuthor:      pulumi.String("Holochain Repository Automation"),
-		CommitEmail:       pulumi.String("hra@holochain.org"),
-		OverwriteOnCreate: pulumi.Bool(true),
-	}, pulumi.DependsOn([]pulumi.Resource{file}), pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true))
-	if err != nil {
-		return err
+func AddClaudeCodeOauthTokenSecret(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
 	}
 
-	_, err = github.NewRepositoryPullRequest(ctx, fmt.Sprintf("%s-contributing-md-pr", name), &github.RepositoryPullRequestArgs{
-		BaseRepository: repository.Name,
-		BaseRef:        baseBranch,
-		HeadRef:        branch.Branch,
-		Title:          pulumi.String("chore: update the CONTRIBUTING.md and AI_POLICY.md with shared content"),
-		Body:           pulumi.String("This PR updates the CONTRIBUTING.md and AI_POLICY.md files with the content from the shared files in the hc-github-config repo."),
-	}, pulumi.DependsOn([]pulumi.Resource{file, aiPolicyFile}), pulumi.ReplacementTrigger(contentHash), pulumi.DeleteBeforeReplace(true))
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-claude-code-oauth-token", repository),
+		SecretName:   "CLAUDE_CODE_OAUTH_TOKEN",
+		Key:          "claudeCodeOauthToken",
+	})
 }
 
-// AddCodeOwners adds the shared CODEOWNERS file to the repository
-// via a pull request against the `main` branch if the content has changed
-// since the last deployment.
-func AddCodeOwners(ctx *pulumi.Context, name string, repository *github.Repository) error {
-	contentHash := pulumi.String(fmt.Sprintf("%x", sha256.Sum256([]byte(codeOwnersContent))))
-
-	baseBranch := pulumi.String("main")
-
-	// See AddContributingGuide for why the branch and file are retained on delete.
-	branch, err := github.NewBranch(ctx, fmt.Sprintf("%s-code-owners-branch", name), &github.BranchArgs{
-		Repository:   repository.Name,
-		Branch:       pulumi.String("chore/update-code-owners"),
-		SourceBranch: baseBranch,
-	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true))
-	if err != nil {
-		return err
-	}
-
-	file, err := github.NewRepositoryFile(ctx, fmt.Sprintf("%s-code-owners-file", name), &github.RepositoryFileArgs{
-		Repository:        repository.Name,
-		Branch:            branch.Branch,
-		File:              pulumi.String(".github/CODEOWNERS"),
-		Content:           pulumi.String(codeOwnersContent),
-		CommitMessage:     pulumi.String("chore: update CODEOWNERS with shared content"),
-		CommitAuthor:      pulumi.String("holochain-release-automation2"),
-		CommitEmail:       pulumi.String("hra@holochain.org"),
-		OverwriteOnCreate: pulumi.Bool(true),
-	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true))
-	if err != nil {
-		return err
+func AddThreefoldTfChainWalletMnemonic(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
 	}
 
-	_, err = github.NewRepositoryPullRequest(ctx, fmt.Sprintf("%s-codeowners-pr", name), &github.RepositoryPullRequestArgs{
-		BaseRepository: repository.Name,
-		BaseRef:        baseBranch,
-		HeadRef:        branch.Branch,
-		Title:          pulumi.String("chore: update CODEOWNERS with shared content"),
-		Body:           pulumi.String("This PR updates CODEOWNERS with the content from the shared file in the hc-github-config repo."),
-	}, pulumi.DependsOn([]pulumi.Resource{file}), pulumi.ReplacementTrigger(contentHash), pulumi.DeleteBeforeReplace(true))
-	return err
-}
-
-type DependabotConfig struct {
-	EnableRust bool
-	EnableNpm  bool
-	EnableGo   bool
-	EnableNix  bool
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-threefold-tfchain-wallet-mnemonic", repository),
+		SecretName:   "THREEFOLD_TFCHAIN_WALLET_MNEMONIC",
+		Key:          "threefoldTfChainWalletMnemonic",
+	})
 }
 
-func AddDependabotYml(ctx *pulumi.Context, name string, repository *github.Repository, config DependabotConfig) error {
-	tmpl, err := template.New("dependabot").Parse(dependabotYmlContent)
-	if err != nil {
-		return err
-	}
-
-	var tpl bytes.Buffer
-	if err := tmpl.Execute(&tpl, config); err != nil {
-		return err
+func AddThreefoldHubApiToken(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
 	}
 
-	generatedDependabotYml := tpl.String()
-
-	contentHash := pulumi.String(fmt.Sprintf("%x", sha256.Sum256([]byte(generatedDependabotYml))))
-
-	baseBranch := pulumi.String("main")
-
-	// See AddContributingGuide for why the branch and file are retained on delete.
-	branch, err := github.NewBranch(ctx, fmt.Sprintf("%s-dependabot-yml-branch", name), &github.BranchArgs{
-		Repository:   repository.Name,
-		Branch:       pulumi.String("chore/update-dependabot-yml"),
-		SourceBranch: baseBranch,
-	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true))
-	if err != nil {
-		return err
-	}
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-threefold-hub-api-token", repository),
+		SecretName:   "THREEFOLD_HUB_API_TOKEN",
+		Key:          "threefoldHubApiToken",
+	})
+}
 
-	file, err := github.NewRepositoryFile(ctx, fmt.Sprintf("%s-dependabot-yml-file", name), &github.RepositoryFileArgs{
-		Repository:        repository.Name,
-		Branch:            branch.Branch,
-		File:              pulumi.String(".github/dependabot.yml"),
-		Content:           pulumi.String(generatedDependabotYml),
-		CommitMessage:     pulumi.String("chore: update dependabot.yml with shared content"),
-		CommitAuthor:      pulumi.String("holochain-release-automation2"),
-		CommitEmail:       pulumi.String("hra@holochain.org"),
-		OverwriteOnCreate: pulumi.Bool(true),
-	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true))
-	if err != nil {
-		return err
+func AddHolochainNotifierMattermostBotPersonalAccessToken(ctx *pulumi.Context, repository string) error {
+	if isRetired(repository) {
+		return nil
 	}
 
-	_, err = github.NewRepositoryPullRequest(ctx, fmt.Sprintf("%s-dependabot-yml-pr", name), &github.RepositoryPullRequestArgs{
-		BaseRepository: repository.Name,
-		BaseRef:        baseBranch,
-		HeadRef:        branch.Branch,
-		Title:          pulumi.String("chore: update dependabot.yml with shared content"),
-		Body:           pulumi.String("This PR updates dependeabot.yml with the content from the shared file in the hc-github-config repo."),
-	}, pulumi.DependsOn([]pulumi.Resource{file}), pulumi.ReplacementTrigger(contentHash), pulumi.DeleteBeforeReplace(true))
-	return err
+	return deploySecret(ctx, SecretDeployment{
+		Repository:   repository,
+		Scope:        SecretScopeActions,
+		ResourceName: fmt.Sprintf("%s-holochain-notifier-mattermost-bot-personal-access-token", repository),
+		SecretName:   "HOLOCHAIN_NOTIFIER_MATTERMOST_BOT_PERSONAL_ACCESS_TOKEN",
+		Key:          "holochainNotifierMattermostBotPersonalAccessToken",
+	})
 }
 
 func AddOutsideCollaborator(ctx *pulumi.Context, name string, repository *github.Repository, username string) error {
+	if isRetired(name) {
+		return nil
+	}
+
 	_, err := github.NewRepositoryCollaborator(ctx, fmt.Sprintf("%s-outside-collab-%s", name, username), &github.RepositoryCollaboratorArgs{
 		Permission: pulumi.String("push"),
 		Repository: repository.Name,
 		Username:   pulumi.Sprintf(username),
-	})
+	}, InRepository(ctx, name))
 
 	return err
 }
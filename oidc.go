@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// organizationOidcClaimKeys are the claims in the subject of the OIDC tokens of repositories that
+// don't choose their own, which matches GitHub's default subject, such as
+// `repo:holochain/lair:ref:refs/heads/main`.
+var organizationOidcClaimKeys = []string{"repo", "context"}
+
+// oidcClaimKeys are the claims that GitHub can include in the subject of an OIDC token.
+var oidcClaimKeys = []string{
+	"actor", "actor_id", "base_ref", "context", "environment", "event_name", "head_ref", "job_workflow_ref",
+	"job_workflow_sha", "ref", "ref_type", "repo", "repository", "repository_id", "repository_owner",
+	"repository_owner_id", "repository_visibility", "run_attempt", "run_id", "run_number", "runner_environment",
+	"sha", "workflow", "workflow_ref", "workflow_sha",
+}
+
+// oidcRepositoryClaimKeys identify the repository, one of which every subject must include so that a
+// cloud provider's trust policy can't be satisfied by another repository in the organization.
+var oidcRepositoryClaimKeys = []string{"repo", "repository", "repository_id"}
+
+// SyncOrganizationOidcSubjectClaims sets the claims in the subject of the organization's OIDC tokens,
+// which repositories use unless they choose their own with AddOidcSubjectClaims. The organization's
+// existing template is imported.
+func SyncOrganizationOidcSubjectClaims(ctx *pulumi.Context) error {
+	_, err := github.NewActionsOrganizationOidcSubjectClaimCustomizationTemplate(ctx, "organization-oidc-subject-claims", &github.ActionsOrganizationOidcSubjectClaimCustomizationTemplateArgs{
+		IncludeClaimKeys: pulumi.ToStringArray(organizationOidcClaimKeys),
+	}, pulumi.Import(pulumi.ID(githubOrganization)))
+
+	return err
+}
+
+// AddOidcSubjectClaims chooses the claims in the subject of a repository's OIDC tokens, so that its
+// workflows can be given short-lived credentials by a cloud provider that trusts tokens with a
+// matching subject, instead of a static credential. For example, `repo` and `environment` let a
+// trust policy accept only deployments to one of the repository's environments.
+func AddOidcSubjectClaims(ctx *pulumi.Context, name string, repository *github.Repository, claimKeys ...string) error {
+	if isRetired(name) {
+		return nil
+	}
+
+	if len(claimKeys) == 0 {
+		invalid(ctx, name, "at least one OIDC subject claim must be chosen")
+		return nil
+	}
+	for _, claimKey := range claimKeys {
+		if !slices.Contains(oidcClaimKeys, claimKey) {
+			invalid(ctx, name, "unknown OIDC subject claim %q", claimKey)
+			return nil
+		}
+	}
+	if !slices.ContainsFunc(claimKeys, func(claimKey string) bool { return slices.Contains(oidcRepositoryClaimKeys, claimKey) }) {
+		invalid(ctx, name, "the OIDC subject claims must include one of %s to identify the repository", strings.Join(oidcRepositoryClaimKeys, ", "))
+		return nil
+	}
+
+	stateFor(ctx).oidcSubjectClaims[name] = claimKeys
+	_, err := github.NewActionsRepositoryOidcSubjectClaimCustomizationTemplate(ctx, fmt.Sprintf("%s-oidc-subject-claims", name), &github.ActionsRepositoryOidcSubjectClaimCustomizationTemplateArgs{
+		Repository:       repository.Name,
+		UseDefault:       pulumi.Bool(false),
+		IncludeClaimKeys: pulumi.ToStringArray(claimKeys),
+	}, InRepository(ctx, name))
+
+	return err
+}
+
+// ExportOidcSubjectClaims exports the claims in the subject of the OIDC tokens of each repository
+// that chooses its own as the `oidcSubjectClaims` stack output, and the organization's claims as
+// the `organizationOidcSubjectClaims` stack output, for writing trust policies.
+func ExportOidcSubjectClaims(ctx *pulumi.Context) {
+	ctx.Export("oidcSubjectClaims", pulumi.ToOutput(stateFor(ctx).oidcSubjectClaims))
+	ctx.Export("organizationOidcSubjectClaims", pulumi.ToStringArray(organizationOidcClaimKeys))
+}
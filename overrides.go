@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// RepositoryOverride replaces one of the settings from StandardRepositoryArgs. Every override is
+// either Intentional, and kept with a reason, or an ImportShim, which is only there so that an
+// existing repository can be imported without changes and is removed once it has been imported.
+type RepositoryOverride struct {
+	// Field is the name of the github.RepositoryArgs field, for example `AllowRebaseMerge`.
+	Field string
+	Value pulumi.Input
+	// Reason is why an intentional override is kept.
+	Reason string
+	// ImportedOn is the date, as YYYY-MM-DD, that an import shim was added.
+	ImportedOn string
+}
+
+// Intentional overrides a standard setting for a reason that still applies.
+func Intentional(field string, value pulumi.Input, reason string) RepositoryOverride {
+	return RepositoryOverride{Field: field, Value: value, Reason: reason}
+}
+
+// ImportShim overrides a standard setting until the repository has been imported. importedOn is
+// the date the shim was added, as YYYY-MM-DD.
+func ImportShim(field string, value pulumi.Input, importedOn string) RepositoryOverride {
+	return RepositoryOverride{Field: field, Value: value, ImportedOn: importedOn}
+}
+
+// IsImportShim reports whether the override is temporary.
+func (override RepositoryOverride) IsImportShim() bool {
+	return override.ImportedOn != ""
+}
+
+// importShimWarningAge is how long an import shim can be kept before a warning is logged.
+const importShimWarningAge = 30 * 24 * time.Hour
+
+// notOverridable are fields that are set by StandardRepositoryArgs from its arguments, or by the
+// repository's lifecycle, rather than overridden.
+var notOverridable = []string{"Name", "Description", "Archived"}
+
+func (override RepositoryOverride) validate() error {
+	field, ok := reflect.TypeOf(github.RepositoryArgs{}).FieldByName(override.Field)
+	if !ok || slices.Contains(notOverridable, override.Field) {
+		return fmt.Errorf("%s is not a repository setting that can be overridden", override.Field)
+	}
+	if override.Value == nil || !reflect.TypeOf(override.Value).AssignableTo(field.Type) {
+		return fmt.Errorf("the override of %s must be a %s", override.Field, field.Type)
+	}
+	if override.IsImportShim() {
+		if _, err := time.Parse(time.DateOnly, override.ImportedOn); err != nil {
+			return fmt.Errorf("the import shim for %s must have a date as YYYY-MM-DD: %w", override.Field, err)
+		}
+		if override.Reason != "" {
+			return fmt.Errorf("the override of %s must either be intentional or an import shim", override.Field)
+		}
+	} else if strings.TrimSpace(override.Reason) == "" {
+		return fmt.Errorf("the intentional override of %s must have a reason", override.Field)
+	}
+
+	return nil
+}
+
+// applyRepositoryOverrides sets the overridden fields on args and records the overrides for
+// ExportRepositoryOverridesReport. Invalid overrides are recorded as validation problems and
+// skipped.
+func applyRepositoryOverrides(ctx *pulumi.Context, name string, args *github.RepositoryArgs, overrides []RepositoryOverride) {
+	var fields []string
+	for _, override := range overrides {
+		if err := override.validate(); err != nil {
+			invalid(ctx, name, "%v", err)
+			continue
+		}
+		if slices.Contains(fields, override.Field) {
+			invalid(ctx, name, "%s is overridden more than once", override.Field)
+			continue
+		}
+		fields = append(fields, override.Field)
+
+		reflect.ValueOf(args).Elem().FieldByName(override.Field).Set(reflect.ValueOf(override.Value))
+		state := stateFor(ctx)
+		state.repositoryOverrides = append(state.repositoryOverrides, repositoryOverride{repository: name, RepositoryOverride: override})
+	}
+}
+
+// repositoryOverride is an override of the named repository.
+type repositoryOverride struct {
+	repository string
+	RepositoryOverride
+}
+
+// RepositoryOverrideReport is an override in the `repositoryOverrides` stack output.
+type RepositoryOverrideReport struct {
+	Repository string `pulumi:"repository"`
+	Field      string `pulumi:"field"`
+	Reason     string `pulumi:"reason"`
+	ImportShim bool   `pulumi:"importShim"`
+	ImportedOn string `pulumi:"importedOn"`
+	// DaysKept is how many days an import shim has been kept for.
+	DaysKept int `pulumi:"daysKept"`
+}
+
+// ExportRepositoryOverridesReport exports every override of the standard repository settings as
+// the `repositoryOverrides` stack output, and the repositories that still have import shims as a
+// Markdown table in the `importShimsReport` stack output. A warning is logged for import shims
+// that have been kept for more than 30 days.
+func ExportRepositoryOverridesReport(ctx *pulumi.Context) {
+	now := time.Now()
+	var reports []RepositoryOverrideReport
+	for _, override := range stateFor(ctx).repositoryOverrides {
+		report := RepositoryOverrideReport{
+			Repository: override.repository,
+			Field:      override.Field,
+			Reason:     override.Reason,
+			ImportShim: override.IsImportShim(),
+			ImportedOn: override.ImportedOn,
+		}
+		if override.IsImportShim() {
+			importedOn, _ := time.Parse(time.DateOnly, override.ImportedOn)
+			report.DaysKept = int(now.Sub(importedOn).Hours() / 24)
+			if now.Sub(importedOn) > importShimWarningAge && !isValidating(ctx) {
+				_ = ctx.Log.Warn(fmt.Sprintf("%s has had an import shim for %s since %s, converge it to the standard setting and remove the shim", override.repository, override.Field, override.ImportedOn), nil)
+			}
+		}
+		reports = append(reports, report)
+	}
+
+	ctx.Export("repositoryOverrides", pulumi.ToOutput(reports))
+	ctx.Export("importShimsReport", pulumi.String(importShimsMarkdown(reports)))
+}
+
+func importShimsMarkdown(reports []RepositoryOverrideReport) string {
+	var shims []RepositoryOverrideReport
+	for _, report := range reports {
+		if report.ImportShim {
+			shims = append(shims, report)
+		}
+	}
+	slices.SortStableFunc(shims, func(a, b RepositoryOverrideReport) int {
+		return b.DaysKept - a.DaysKept
+	})
+
+	var markdown strings.Builder
+	markdown.WriteString("# Import shims\n\n")
+	if len(shims) == 0 {
+		markdown.WriteString("No repositories have import shims.\n")
+		return markdown.String()
+	}
+
+	markdown.WriteString("| Repository | Setting | Imported on | Days kept |\n")
+	markdown.WriteString("|------------|---------|-------------|-----------|\n")
+	for _, shim := range shims {
+		fmt.Fprintf(&markdown, "| %s | `%s` | %s | %d |\n", shim.Repository, shim.Field, shim.ImportedOn, shim.DaysKept)
+	}
+
+	return markdown.String()
+}
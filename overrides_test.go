@@ -0,0 +1,108 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestRepositoryOverrideValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		override RepositoryOverride
+		expected string
+	}{
+		{
+			name:     "intentional",
+			override: Intentional("HasWiki", pulumi.Bool(true), "The wiki is used for the design notes"),
+		},
+		{
+			name:     "import shim",
+			override: ImportShim("AllowSquashMerge", pulumi.Bool(true), "2026-10-19"),
+		},
+		{
+			name:     "unknown field",
+			override: Intentional("HasWikis", pulumi.Bool(true), "The wiki is used for the design notes"),
+			expected: "HasWikis is not a repository setting that can be overridden",
+		},
+		{
+			name:     "set from the arguments",
+			override: Intentional("Description", pulumi.String("A description"), "The description is longer"),
+			expected: "Description is not a repository setting that can be overridden",
+		},
+		{
+			name:     "wrong type",
+			override: Intentional("HasWiki", pulumi.String("true"), "The wiki is used for the design notes"),
+			expected: "the override of HasWiki must be a pulumi.BoolPtrInput",
+		},
+		{
+			name:     "no reason",
+			override: Intentional("HasWiki", pulumi.Bool(true), " "),
+			expected: "the intentional override of HasWiki must have a reason",
+		},
+		{
+			name:     "invalid date",
+			override: ImportShim("HasWiki", pulumi.Bool(true), "19/10/2026"),
+			expected: `the import shim for HasWiki must have a date as YYYY-MM-DD: parsing time "19/10/2026" as "2006-01-02": cannot parse "19/10/2026" as "2006"`,
+		},
+		{
+			name:     "both",
+			override: RepositoryOverride{Field: "HasWiki", Value: pulumi.Bool(true), Reason: "The wiki is used", ImportedOn: "2026-10-19"},
+			expected: "the override of HasWiki must either be intentional or an import shim",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.override.validate()
+			if test.expected == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if test.expected != "" && (err == nil || err.Error() != test.expected) {
+				t.Errorf("expected %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestApplyRepositoryOverrides(t *testing.T) {
+	var args github.RepositoryArgs
+	state, _, err := declareWithMocks("holochain", "github", map[string]string{}, func(ctx *pulumi.Context) error {
+		description := "secret lair private keystore"
+		args = StandardRepositoryArgs(ctx, "lair", &description,
+			Intentional("HasWiki", pulumi.Bool(true), "The wiki is used for the design notes"),
+			ImportShim("AllowSquashMerge", pulumi.Bool(true), "2026-10-19"),
+			ImportShim("HasWiki", pulumi.Bool(false), "2026-10-19"),
+			Intentional("Description", pulumi.String("A description"), "The description is longer"),
+		)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(args.HasWiki, pulumi.Bool(true)) || !reflect.DeepEqual(args.AllowSquashMerge, pulumi.Bool(true)) {
+		t.Errorf("expected HasWiki and AllowSquashMerge to be overridden, got %v and %v", args.HasWiki, args.AllowSquashMerge)
+	}
+	if !reflect.DeepEqual(args.Description, pulumi.String("secret lair private keystore")) {
+		t.Errorf("expected the description to be kept, got %v", args.Description)
+	}
+
+	var fields []string
+	for _, override := range state.repositoryOverrides {
+		fields = append(fields, override.repository+" "+override.Field)
+	}
+	if expected := []string{"lair HasWiki", "lair AllowSquashMerge"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected the overrides %v, got %v", expected, fields)
+	}
+
+	expectedErrors := ValidationErrors{
+		{Repository: "lair", Problem: "HasWiki is overridden more than once"},
+		{Repository: "lair", Problem: "Description is not a repository setting that can be overridden"},
+	}
+	if !reflect.DeepEqual(state.validationErrors, expectedErrors) {
+		t.Errorf("expected the problems %v, got %v", expectedErrors, state.validationErrors)
+	}
+}
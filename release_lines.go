@@ -0,0 +1,202 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed files/release_lines.yml
+var releaseLinesYmlContent string
+
+// ReleaseLineStatus is how a release line is maintained.
+type ReleaseLineStatus string
+
+const (
+	// ReleaseLineActive lines receive backports of fixes and features.
+	ReleaseLineActive ReleaseLineStatus = "active"
+	// ReleaseLineSecurityOnly lines only receive backports of security fixes.
+	ReleaseLineSecurityOnly ReleaseLineStatus = "security-only"
+	// ReleaseLineEOL lines are no longer maintained.
+	ReleaseLineEOL ReleaseLineStatus = "eol"
+)
+
+// ReleaseLine is a minor version of Holochain that is maintained on its own branch.
+type ReleaseLine struct {
+	// Version is the major and minor version, for example `0.6`.
+	Version string            `yaml:"version"`
+	Status  ReleaseLineStatus `yaml:"status"`
+	// LabelAliases are previous names of the backport label, which are renamed to the current name.
+	LabelAliases []string `yaml:"label_aliases"`
+}
+
+// Branch is the branch that the release line is maintained on.
+func (line ReleaseLine) Branch() string {
+	return fmt.Sprintf("main-%s", line.Version)
+}
+
+// BackportLabel is the label for PRs that should be backported to the release line.
+func (line ReleaseLine) BackportLabel() RepositoryLabel {
+	return RepositoryLabel(fmt.Sprintf("ShouldBackport/%s", line.Version))
+}
+
+// Supported reports whether the release line still receives backports.
+func (line ReleaseLine) Supported() bool {
+	return line.Status != ReleaseLineEOL
+}
+
+func (line ReleaseLine) labelDefinition() LabelDefinition {
+	label := LabelDefinition{
+		Name:        line.BackportLabel(),
+		Description: fmt.Sprintf("Post-merge into the default branch; should be backported to the release branch for version %s.x", line.Version),
+		Color:       "0E8A16", // Green
+		Aliases:     line.LabelAliases,
+	}
+	if line.Status == ReleaseLineSecurityOnly {
+		label.Description = fmt.Sprintf("Security fix; should be backported to the %s.x release branch, which only receives security fixes", line.Version)
+		label.Color = "D93F0B" // Orange
+	}
+
+	return label
+}
+
+// backportLabelPattern is the pattern that the generated backport labels must match, which is
+// checked with the rest of the label taxonomy.
+const backportLabelPattern = `^ShouldBackport/[0-9]+\.[0-9]+$`
+
+var releaseLineVersion = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+func parseReleaseLines(content string) ([]ReleaseLine, error) {
+	var file struct {
+		ReleaseLines []ReleaseLine `yaml:"release_lines"`
+	}
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("parsing release_lines.yml: %w", err)
+	}
+
+	versions := map[string]bool{}
+	for _, line := range file.ReleaseLines {
+		if !releaseLineVersion.MatchString(line.Version) {
+			return nil, fmt.Errorf("invalid release_lines.yml: version %q must be a major and minor version, such as 0.6", line.Version)
+		}
+		if versions[line.Version] {
+			return nil, fmt.Errorf("invalid release_lines.yml: version %s is defined more than once", line.Version)
+		}
+		versions[line.Version] = true
+		switch line.Status {
+		case ReleaseLineActive, ReleaseLineSecurityOnly, ReleaseLineEOL:
+		default:
+			return nil, fmt.Errorf("invalid release_lines.yml: version %s has status %q, which must be active, security-only or eol", line.Version, line.Status)
+		}
+	}
+
+	return file.ReleaseLines, nil
+}
+
+// ReleaseLinesConfig controls what is managed on a repository for each release line.
+type ReleaseLinesConfig struct {
+	// CreateBranches creates the `main-<version>` branch of each supported release line from the
+	// default branch, if the repository doesn't already have it.
+	CreateBranches bool
+	// ProtectBranches adds the `release` ruleset from ReleaseRepositoryRulesetArgs, which covers the
+	// `main-*` branches. Don't set it if the repository already declares a release ruleset.
+	ProtectBranches bool
+}
+
+// AddReleaseLines adds the backport label of each supported release line to a repository, and
+// removes the labels of release lines that have reached end of life.
+func AddReleaseLines(ctx *pulumi.Context, name string, repository *github.Repository, config ReleaseLinesConfig) error {
+	if labelTaxonomyErr != nil {
+		invalid(ctx, "", "%v", labelTaxonomyErr)
+		return nil
+	}
+
+	if err := AddLabels(ctx, name, repository, RepositoryLabelsConfig{Sets: []LabelSet{LabelSetBackport}}); err != nil {
+		return err
+	}
+	for _, line := range releaseLines {
+		if !line.Supported() {
+			removeRepositoryLabel(ctx, name, line.BackportLabel())
+		}
+	}
+
+	if config.CreateBranches {
+		for _, line := range releaseLines {
+			if !line.Supported() {
+				continue
+			}
+			if err := addReleaseBranch(ctx, name, repository, line); err != nil {
+				return err
+			}
+		}
+	}
+
+	if config.ProtectBranches {
+		releaseRepositoryRulesetArgs := ReleaseRepositoryRulesetArgs(repository, NewRulesetOptions(ctx, name))
+		if _, err := github.NewRepositoryRuleset(ctx, fmt.Sprintf("%s-release", name), &releaseRepositoryRulesetArgs, InRepository(ctx, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addReleaseBranch creates the branch for a release line, or adopts it if it already exists. The
+// branch is retained when the release line reaches end of life.
+func addReleaseBranch(ctx *pulumi.Context, name string, repository *github.Repository, line ReleaseLine) error {
+	opts := []pulumi.ResourceOption{pulumi.RetainOnDelete(true), InRepository(ctx, name)}
+
+	existing, err := github.LookupBranch(ctx, &github.LookupBranchArgs{
+		Repository: name,
+		Branch:     line.Branch(),
+	})
+	err = lookupError(err)
+	if err != nil && !errors.Is(err, errGithubNotFound) {
+		return fmt.Errorf("looking up %s on %s: %w", line.Branch(), name, err)
+	}
+	if err == nil && existing.Ref != "" {
+		opts = append(opts, pulumi.Import(pulumi.ID(fmt.Sprintf("%s:%s:main", name, line.Branch()))))
+	}
+
+	_, err = github.NewBranch(ctx, fmt.Sprintf("%s-%s-branch", name, line.Branch()), &github.BranchArgs{
+		Repository:   repository.Name,
+		Branch:       pulumi.String(line.Branch()),
+		SourceBranch: pulumi.String("main"),
+	}, opts...)
+
+	return err
+}
+
+// removeRepositoryLabel records that a label should no longer be on a repository. Authoritative
+// repositories drop it from their IssueLabels, and others have it deleted by SyncRepositoryLabels.
+func removeRepositoryLabel(ctx *pulumi.Context, name string, label RepositoryLabel) {
+	declared := stateFor(ctx).labelsFor(name)
+	if declared == nil {
+		invalid(ctx, name, "labels must be added before %q can be removed", label)
+		return
+	}
+	declared.removed = append(declared.removed, label)
+}
+
+// deleteLabel deletes a label from a repository, returning false if it didn't exist.
+func (api *githubAPI) deleteLabel(repository string, label RepositoryLabel) (bool, error) {
+	path := fmt.Sprintf("/repos/%s/%s/labels/%s", githubOrganization, repository, url.PathEscape(string(label)))
+	if err := api.do(http.MethodDelete, path, nil, nil); err != nil {
+		if errors.Is(err, errGithubNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
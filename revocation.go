@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// revokedSecretTombstone replaces the value of revoked secrets when `tombstoneRevokedSecrets` is set.
+const revokedSecretTombstone = "revoked"
+
+// revokedSecrets returns the full configuration keys in `revokedSecrets`, whose secrets are removed
+// from every repository, or replaced with a tombstone if `tombstoneRevokedSecrets` is set.
+func revokedSecrets(ctx *pulumi.Context) []string {
+	var keys []string
+	if err := config.GetObject(ctx, "holochain:revokedSecrets", &keys); err != nil {
+		invalid(ctx, "", "holochain:revokedSecrets must be a list of configuration keys: %v", err)
+	}
+
+	return keys
+}
+
+// secretURN returns the URN of the resource for a deployment, in the HolochainRepository component
+// of its repository if it has one.
+func secretURN(ctx *pulumi.Context, deployment SecretDeployment) string {
+	resourceType := "github:index/actionsSecret:ActionsSecret"
+	if deployment.Scope == SecretScopeDependabot {
+		resourceType = "github:index/dependabotSecret:DependabotSecret"
+	}
+	if _, ok := stateFor(ctx).repositoryComponents[deployment.Repository]; ok {
+		resourceType = HolochainRepositoryType + "$" + resourceType
+	}
+
+	return fmt.Sprintf("urn:pulumi:%s::%s::%s::%s", ctx.Stack(), ctx.Project(), resourceType, deployment.ResourceName)
+}
+
+// RevokeSecretCommand is the command that prints how to revoke a leaked secret, run with
+// `go run . revoke-secret [-stack <stack>] [-tombstone] <key>`.
+const RevokeSecretCommand = "revoke-secret"
+
+// runRevokeSecret finds every repository that a secret is deployed to, lists the workflows in those
+// repositories that read it if GITHUB_TOKEN is set, and prints the commands that revoke it in a
+// single targeted update.
+func runRevokeSecret(args []string, declare pulumi.RunFunc) error {
+	flags := flag.NewFlagSet(RevokeSecretCommand, flag.ContinueOnError)
+	stack := flags.String("stack", "github", "the stack the secret is revoked from")
+	tombstone := flags.Bool("tombstone", false, "replace the secret with a tombstone value instead of deleting it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: go run . %s [-stack <stack>] [-tombstone] <key>", RevokeSecretCommand)
+	}
+	project, err := projectName()
+	if err != nil {
+		return err
+	}
+	key := flags.Arg(0)
+	if !strings.Contains(key, ":") {
+		key = project + ":" + key
+	}
+
+	stackConfig, err := readStackConfig(project, *stack)
+	if err != nil {
+		return err
+	}
+	var revoked []string
+	if value, ok := stackConfig.values["holochain:revokedSecrets"]; ok {
+		if err := json.Unmarshal([]byte(value), &revoked); err != nil {
+			return fmt.Errorf("holochain:revokedSecrets must be a list of configuration keys: %w", err)
+		}
+	}
+	if slices.Contains(revoked, key) {
+		return fmt.Errorf("%s is already in holochain:revokedSecrets", key)
+	}
+
+	state, _, err := declareWithMocks(project, *stack, stackConfig.values, declare)
+	if err != nil {
+		return fmt.Errorf("declaring the %s stack: %w", *stack, err)
+	}
+	var deployments []SecretDeployment
+	for _, deployment := range state.secretDeployments {
+		if deployment.Key == key {
+			deployments = append(deployments, deployment)
+		}
+	}
+	if len(deployments) == 0 {
+		return fmt.Errorf("%s is not deployed to any repository in the %s stack", key, *stack)
+	}
+
+	fmt.Printf("%s is deployed as:\n", key)
+	for _, deployment := range deployments {
+		fmt.Printf("  - %s: %s (%s)\n", deployment.Repository, deployment.SecretName, deployment.Scope)
+	}
+
+	fmt.Println()
+	if token := os.Getenv("GITHUB_TOKEN"); token == "" {
+		fmt.Println("Set GITHUB_TOKEN, for example with GITHUB_TOKEN=$(gh auth token), to list the workflows that read the secret.")
+	} else {
+		api := newGithubAPIWithToken(token)
+		fmt.Println("Workflows that read the secret:")
+		found := false
+		for _, deployment := range deployments {
+			workflows, err := api.workflowsReadingSecret(deployment.Repository, deployment.SecretName)
+			if err != nil {
+				return fmt.Errorf("reading the workflows of %s: %w", deployment.Repository, err)
+			}
+			for _, workflow := range workflows {
+				fmt.Printf("  - %s: %s reads %s\n", deployment.Repository, workflow, deployment.SecretName)
+				found = true
+			}
+		}
+		if !found {
+			fmt.Println("  none")
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("To revoke it, run:")
+	fmt.Printf("  pulumi config set --stack %s --path 'holochain:revokedSecrets[%d]' %s\n", *stack, len(revoked), key)
+	if *tombstone {
+		fmt.Printf("  pulumi config set --stack %s holochain:tombstoneRevokedSecrets true\n", *stack)
+	}
+	targets := []string{fmt.Sprintf("pulumi up --stack %s", *stack)}
+	for _, deployment := range deployments {
+		targets = append(targets, fmt.Sprintf("--target '%s'", deployment.urn))
+	}
+	fmt.Printf("  %s\n", strings.Join(targets, " \\\n      "))
+
+	return nil
+}
+
+// workflowDirectories are the directories of a repository with workflows and composite actions that
+// can read secrets.
+var workflowDirectories = []string{".github/workflows", ".github/actions"}
+
+// workflowsReadingSecret returns the paths of the workflows and composite actions in a repository
+// that read the secret.
+func (api *githubAPI) workflowsReadingSecret(repository string, secretName string) ([]string, error) {
+	name := regexp.QuoteMeta(secretName)
+	reference := regexp.MustCompile(fmt.Sprintf(`secrets\s*(\.\s*%s\b|\[\s*['"]%s['"]\s*\])`, name, name))
+
+	var workflows []string
+	directories := slices.Clone(workflowDirectories)
+	for len(directories) > 0 {
+		directory := directories[0]
+		directories = directories[1:]
+
+		var entries []githubContent
+		if err := api.do(http.MethodGet, fmt.Sprintf("/repos/%s/%s/contents/%s", githubOrganization, repository, directory), nil, &entries); err != nil {
+			if errors.Is(err, errGithubNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.Type == "dir" {
+				directories = append(directories, entry.Path)
+				continue
+			}
+			if entry.Type != "file" || !(strings.HasSuffix(entry.Name, ".yml") || strings.HasSuffix(entry.Name, ".yaml")) {
+				continue
+			}
+			content, err := api.fileContent(repository, entry.Path, "")
+			if err != nil {
+				return nil, err
+			}
+			if reference.MatchString(content) {
+				workflows = append(workflows, entry.Path)
+			}
+		}
+	}
+
+	return workflows, nil
+}
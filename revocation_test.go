@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestWorkflowsReadingSecret(t *testing.T) {
+	responses := map[string]string{
+		"/repos/holochain/lair/contents/.github/workflows": `[
+			{"name": "release.yaml", "path": ".github/workflows/release.yaml", "type": "file"},
+			{"name": "publish.yml", "path": ".github/workflows/publish.yml", "type": "file"},
+			{"name": "test.yaml", "path": ".github/workflows/test.yaml", "type": "file"},
+			{"name": "README.md", "path": ".github/workflows/README.md", "type": "file"}
+		]`,
+		"/repos/holochain/lair/contents/.github/actions": `[
+			{"name": "setup", "path": ".github/actions/setup", "type": "dir"}
+		]`,
+		"/repos/holochain/lair/contents/.github/actions/setup": `[
+			{"name": "action.yml", "path": ".github/actions/setup/action.yml", "type": "file"}
+		]`,
+		"/repos/holochain/lair/contents/.github/workflows/release.yaml":   `{"path": ".github/workflows/release.yaml", "content": "ZW52OgogIFRPS0VOOiAke3sgc2VjcmV0cy5IUkEyX0dJVEhVQl9UT0tFTiB9fQo="}`,
+		"/repos/holochain/lair/contents/.github/workflows/publish.yml":    `{"path": ".github/workflows/publish.yml", "content": "ZW52OgogIFRPS0VOOiAke3sgc2VjcmV0c1snSFJBMl9HSVRIVUJfVE9LRU4nXSB9fQo="}`,
+		"/repos/holochain/lair/contents/.github/workflows/test.yaml":      `{"path": ".github/workflows/test.yaml", "content": "ZW52OgogIFRPS0VOOiAke3sgc2VjcmV0cy5IUkEyX0dJVEhVQl9UT0tFTl8yIH19Cg=="}`,
+		"/repos/holochain/lair/contents/.github/actions/setup/action.yml": `{"path": ".github/actions/setup/action.yml", "content": "cnVuczoKICB1c2luZzogY29tcG9zaXRlCiAgc3RlcHM6CiAgICAtIHJ1bjogZWNobyAke3sgc2VjcmV0cyAuIEhSQTJfR0lUSFVCX1RPS0VOIH19Cg=="}`,
+		"/repos/holochain/tx5/contents/.github/workflows":                 `[]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	api := newGithubAPIWithToken("token")
+	api.baseURL = server.URL
+
+	tests := []struct {
+		repository string
+		want       []string
+	}{
+		{
+			repository: "lair",
+			want:       []string{".github/workflows/release.yaml", ".github/workflows/publish.yml", ".github/actions/setup/action.yml"},
+		},
+		{
+			repository: "tx5",
+		},
+		{
+			repository: "sbd",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.repository, func(t *testing.T) {
+			workflows, err := api.workflowsReadingSecret(test.repository, "HRA2_GITHUB_TOKEN")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(workflows, test.want) {
+				t.Errorf("workflowsReadingSecret() = %v, want %v", workflows, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// RunnerGroup is an organization group of self-hosted runners.
+type RunnerGroup string
+
+const (
+	// RunnerGroupPerformance runs the Wind Tunnel performance tests on dedicated machines.
+	RunnerGroupPerformance RunnerGroup = "performance"
+)
+
+// RunnerGroupConfig is who can use the runners in a group.
+type RunnerGroupConfig struct {
+	// Visibility is `selected` for the repositories added with AddRunnerGroup, or `all` for every
+	// repository in the organization.
+	Visibility string
+	// AllowsPublicRepositories lets public repositories use the runners. Pull requests from forks can
+	// run code on runners that public repositories use, so SelectedWorkflows must be set as well.
+	AllowsPublicRepositories bool
+	// SelectedWorkflows are the only workflows that can use the runners, such as
+	// `holochain/wind-tunnel/.github/workflows/performance.yaml@refs/heads/main`. Any workflow in the
+	// group's repositories can use them when this is empty.
+	SelectedWorkflows []string
+}
+
+// runnerGroups are the organization's runner groups.
+var runnerGroups = map[RunnerGroup]RunnerGroupConfig{
+	RunnerGroupPerformance: {
+		Visibility:               "selected",
+		AllowsPublicRepositories: true,
+		SelectedWorkflows:        []string{"holochain/wind-tunnel/.github/workflows/performance.yaml@refs/heads/main"},
+	},
+}
+
+// existingRunnerGroup is a runner group that was created in the GitHub settings, from
+// `existingRunnerGroups` in the configuration.
+type existingRunnerGroup struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// AddRunnerGroup gives a repository access to the runners in a group.
+func AddRunnerGroup(ctx *pulumi.Context, repository string, group RunnerGroup) {
+	if isRetired(repository) {
+		return
+	}
+
+	groupConfig, ok := runnerGroups[group]
+	if !ok {
+		invalid(ctx, repository, "unknown runner group %q", group)
+		return
+	}
+	if groupConfig.Visibility != "selected" {
+		invalid(ctx, repository, "runner group %q is available to %s repositories, so repositories can't be added to it", group, groupConfig.Visibility)
+		return
+	}
+
+	state := stateFor(ctx)
+	if slices.Contains(state.runnerGroupRepositories[group], repository) {
+		return
+	}
+	state.runnerGroupRepositories[group] = append(state.runnerGroupRepositories[group], repository)
+}
+
+// SyncRunnerGroups declares the organization's runner groups, each of which can be used by the
+// repositories added to it with AddRunnerGroup. The groups already exist, so each one is imported by
+// the ID and name in `existingRunnerGroups`, and a group that is missing there fails validation. It
+// must be called after all repositories are declared.
+func SyncRunnerGroups(ctx *pulumi.Context) error {
+	state := stateFor(ctx)
+	var existing map[RunnerGroup]existingRunnerGroup
+	if err := config.GetObject(ctx, "holochain:existingRunnerGroups", &existing); err != nil {
+		invalid(ctx, "", "holochain:existingRunnerGroups must map runner groups to their ID and name: %v", err)
+		return nil
+	}
+	groups := make([]RunnerGroup, 0, len(runnerGroups))
+	for group := range runnerGroups {
+		groups = append(groups, group)
+	}
+	slices.Sort(groups)
+
+	for _, group := range groups {
+		groupConfig := runnerGroups[group]
+		if !slices.Contains([]string{"all", "selected"}, groupConfig.Visibility) {
+			invalid(ctx, "", "runner group %q must be available to all or selected repositories, not %q", group, groupConfig.Visibility)
+			continue
+		}
+		if groupConfig.AllowsPublicRepositories && len(groupConfig.SelectedWorkflows) == 0 {
+			invalid(ctx, "", "runner group %q allows public repositories, so it must only allow selected workflows", group)
+			continue
+		}
+		imported, ok := existing[group]
+		if !ok || imported.ID == 0 || imported.Name == "" {
+			invalid(ctx, "", "runner group %q must have its ID and name in holochain:existingRunnerGroups, so that it is imported rather than created again", group)
+			continue
+		}
+
+		repositories := slices.Clone(state.runnerGroupRepositories[group])
+		slices.Sort(repositories)
+		repositoryIds := pulumi.IntArray{}
+		for _, repository := range repositories {
+			component, ok := state.repositoryComponents[repository]
+			if !ok {
+				invalid(ctx, repository, "runner group %q can only be used by repositories declared with NewHolochainRepository", group)
+				continue
+			}
+			repositoryIds = append(repositoryIds, component.Repository.RepoId)
+		}
+
+		args := &github.ActionsRunnerGroupArgs{
+			Name:                     pulumi.String(imported.Name),
+			Visibility:               pulumi.String(groupConfig.Visibility),
+			AllowsPublicRepositories: pulumi.Bool(groupConfig.AllowsPublicRepositories),
+			RestrictedToWorkflows:    pulumi.Bool(len(groupConfig.SelectedWorkflows) > 0),
+			SelectedWorkflows:        pulumi.ToStringArray(groupConfig.SelectedWorkflows),
+		}
+		if groupConfig.Visibility == "selected" {
+			args.SelectedRepositoryIds = repositoryIds
+		}
+		if _, err := github.NewActionsRunnerGroup(ctx, fmt.Sprintf("runner-group-%s", group), args, pulumi.Import(pulumi.ID(strconv.Itoa(imported.ID)))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestSyncRunnerGroups(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration map[string]string
+		expected      ValidationErrors
+	}{
+		{
+			name:          "imported",
+			configuration: map[string]string{"holochain:existingRunnerGroups": `{"performance": {"id": 3, "name": "Performance"}}`},
+		},
+		{
+			name:          "not in the configuration",
+			configuration: map[string]string{},
+			expected: ValidationErrors{
+				{Problem: `runner group "performance" must have its ID and name in holochain:existingRunnerGroups, so that it is imported rather than created again`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, _, err := declareWithMocks("holochain", "github", test.configuration, func(ctx *pulumi.Context) error {
+				args := standardRepositoryArgs("wind-tunnel", nil)
+				if _, err := NewHolochainRepository(ctx, "wind-tunnel", &args); err != nil {
+					return err
+				}
+				AddRunnerGroup(ctx, "wind-tunnel", RunnerGroupPerformance)
+				return SyncRunnerGroups(ctx)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(state.validationErrors, test.expected) {
+				t.Errorf("expected the problems %v, got %v", test.expected, state.validationErrors)
+			}
+		})
+	}
+}
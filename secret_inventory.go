@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// SecretScope is where a secret is stored in a repository, which decides what can read it.
+type SecretScope string
+
+const (
+	// SecretScopeActions secrets can be read by every workflow in the repository, in any environment.
+	SecretScopeActions SecretScope = "actions"
+	// SecretScopeDependabot secrets can be read by Dependabot updates and the workflows they trigger.
+	SecretScopeDependabot SecretScope = "dependabot"
+)
+
+// SecretDeployment is a secret from the configuration that is stored in a repository.
+type SecretDeployment struct {
+	Repository string
+	Scope      SecretScope
+	// ResourceName is the name of the Pulumi resource.
+	ResourceName string
+	// SecretName is the name that workflows read the secret with.
+	SecretName string
+	// Namespace is the configuration namespace that Key is read from, the project's if it is empty.
+	Namespace string
+	// Key is the configuration key of the secret, in Namespace. Deployments recorded in the program
+	// state have the full key, with its namespace.
+	Key string
+	// Revoked is set on recorded deployments whose key is in `revokedSecrets`.
+	Revoked bool
+
+	urn string
+}
+
+// deploySecret stores a secret from the configuration in a repository, and records the deployment
+// for the secret inventory. Revoked secrets are not stored, so that the next update deletes them,
+// or are replaced with a tombstone value if `tombstoneRevokedSecrets` is set.
+func deploySecret(ctx *pulumi.Context, deployment SecretDeployment, opts ...pulumi.ResourceOption) error {
+	recorded := deployment
+	recorded.Key = configKey(ctx, deployment.Namespace, deployment.Key)
+	recorded.Revoked = slices.Contains(revokedSecrets(ctx), recorded.Key)
+	recorded.urn = secretURN(ctx, deployment)
+	state := stateFor(ctx)
+	for _, existing := range state.secretDeployments {
+		if existing.Repository == recorded.Repository && existing.Scope == recorded.Scope && existing.SecretName == recorded.SecretName && existing.Key != recorded.Key {
+			invalid(ctx, recorded.Repository, "the %s secret %s is given both %s and %s", recorded.Scope, recorded.SecretName, existing.Key, recorded.Key)
+			return nil
+		}
+	}
+	state.secretDeployments = append(state.secretDeployments, recorded)
+
+	var value pulumi.StringOutput
+	switch {
+	case !recorded.Revoked:
+		value = requireSecret(ctx, deployment.Namespace, deployment.Repository, deployment.Key)
+	case config.GetBool(ctx, "holochain:tombstoneRevokedSecrets"):
+		value = pulumi.ToSecret(pulumi.String(revokedSecretTombstone)).(pulumi.StringOutput)
+	default:
+		return nil
+	}
+	opts = append(opts, pulumi.DeleteBeforeReplace(true), pulumi.IgnoreChanges([]string{"encryptedValue"}), InRepository(ctx, deployment.Repository))
+
+	var err error
+	switch deployment.Scope {
+	case SecretScopeActions:
+		_, err = github.NewActionsSecret(ctx, deployment.ResourceName, &github.ActionsSecretArgs{
+			Repository: pulumi.String(deployment.Repository),
+			SecretName: pulumi.String(deployment.SecretName),
+			// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
+			Value: value,
+		}, opts...)
+	case SecretScopeDependabot:
+		_, err = github.NewDependabotSecret(ctx, deployment.ResourceName, &github.DependabotSecretArgs{
+			Repository: pulumi.String(deployment.Repository),
+			SecretName: pulumi.String(deployment.SecretName),
+			// The GitHub API only accepts encrypted values. This will be encrypted by the provider before being sent.
+			Value: value,
+		}, opts...)
+	default:
+		invalid(ctx, deployment.Repository, "%s has an unknown secret scope %q", deployment.SecretName, deployment.Scope)
+	}
+
+	return err
+}
+
+// SecretRiskTier is how much damage a leaked secret could do.
+type SecretRiskTier string
+
+const (
+	// SecretRiskCritical secrets can change the organization, sign releases or move funds.
+	SecretRiskCritical SecretRiskTier = "critical"
+	// SecretRiskHigh secrets can publish releases or change infrastructure.
+	SecretRiskHigh SecretRiskTier = "high"
+	// SecretRiskStandard secrets are scoped to a single service.
+	SecretRiskStandard SecretRiskTier = "standard"
+)
+
+var secretRiskTierOrder = []SecretRiskTier{SecretRiskCritical, SecretRiskHigh, SecretRiskStandard}
+
+// secretRiskTiers are the risk tiers of the secrets that are not standard, by full configuration key.
+var secretRiskTiers = map[string]SecretRiskTier{
+	"holochain:hra2GithubAdminToken":           SecretRiskCritical,
+	"holochain:appleCertificate":               SecretRiskCritical,
+	"holochain:appleCertificatePassword":       SecretRiskCritical,
+	"holochain:appleDevIdentity":               SecretRiskCritical,
+	"holochain:appleIdPassword":                SecretRiskCritical,
+	"holochain:azureClientSecret":              SecretRiskCritical,
+	"holochain:threefoldTfChainWalletMnemonic": SecretRiskCritical,
+
+	"holochain:hra2GithubUserToken":                SecretRiskHigh,
+	"holochain:hra2GithubWorkflowsToken":           SecretRiskHigh,
+	"holochain:hra2CratesIoToken":                  SecretRiskHigh,
+	"holochain:hra2PulumiAccessToken":              SecretRiskHigh,
+	"holochain:githubAppPrivateKey":                SecretRiskHigh,
+	"holochain:hetznerHolochainInfraBucketsSecret": SecretRiskHigh,
+	"holochain:tailscaleOAuthSecret":               SecretRiskHigh,
+	"wind-tunnel:nomadAccessToken":                 SecretRiskHigh,
+}
+
+func secretRiskTier(key string) SecretRiskTier {
+	if tier, ok := secretRiskTiers[key]; ok {
+		return tier
+	}
+
+	return SecretRiskStandard
+}
+
+// SecretInventoryEntry is a secret from the configuration and everywhere it is deployed to.
+type SecretInventoryEntry struct {
+	Key          string                      `pulumi:"key" json:"key"`
+	RiskTier     string                      `pulumi:"riskTier" json:"riskTier"`
+	Revoked      bool                        `pulumi:"revoked" json:"revoked"`
+	Repositories []string                    `pulumi:"repositories" json:"repositories"`
+	Deployments  []SecretInventoryDeployment `pulumi:"deployments" json:"deployments"`
+}
+
+// SecretInventoryDeployment is where a secret is stored.
+type SecretInventoryDeployment struct {
+	Repository string `pulumi:"repository" json:"repository"`
+	Scope      string `pulumi:"scope" json:"scope"`
+	SecretName string `pulumi:"secretName" json:"secretName"`
+}
+
+// secretInventory groups the deployments by secret, with the most dangerous secrets first.
+func secretInventory(deployments []SecretDeployment) []SecretInventoryEntry {
+	var inventory []SecretInventoryEntry
+	for _, deployment := range deployments {
+		index := slices.IndexFunc(inventory, func(entry SecretInventoryEntry) bool { return entry.Key == deployment.Key })
+		if index < 0 {
+			inventory = append(inventory, SecretInventoryEntry{Key: deployment.Key, RiskTier: string(secretRiskTier(deployment.Key)), Revoked: deployment.Revoked})
+			index = len(inventory) - 1
+		}
+		entry := &inventory[index]
+		if !slices.Contains(entry.Repositories, deployment.Repository) {
+			entry.Repositories = append(entry.Repositories, deployment.Repository)
+		}
+		entry.Deployments = append(entry.Deployments, SecretInventoryDeployment{
+			Repository: deployment.Repository,
+			Scope:      string(deployment.Scope),
+			SecretName: deployment.SecretName,
+		})
+	}
+
+	for i := range inventory {
+		slices.Sort(inventory[i].Repositories)
+		slices.SortStableFunc(inventory[i].Deployments, func(a, b SecretInventoryDeployment) int {
+			return strings.Compare(a.Repository, b.Repository)
+		})
+	}
+	slices.SortStableFunc(inventory, func(a, b SecretInventoryEntry) int {
+		tierA := slices.Index(secretRiskTierOrder, SecretRiskTier(a.RiskTier))
+		tierB := slices.Index(secretRiskTierOrder, SecretRiskTier(b.RiskTier))
+		if tierA != tierB {
+			return tierA - tierB
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return inventory
+}
+
+// ExportSecretInventory exports where each secret from the configuration is deployed to as the
+// `secretInventory` stack output, and as a Markdown table in the `secretInventoryReport` stack
+// output. It must be called after all repositories are declared.
+func ExportSecretInventory(ctx *pulumi.Context) {
+	inventory := secretInventory(stateFor(ctx).secretDeployments)
+	ctx.Export("secretInventory", pulumi.ToOutput(inventory))
+	ctx.Export("secretInventoryReport", pulumi.String(secretInventoryMarkdown(inventory)))
+}
+
+func secretInventoryMarkdown(inventory []SecretInventoryEntry) string {
+	var report strings.Builder
+	report.WriteString("# Secret inventory\n\n")
+	for _, tier := range secretRiskTierOrder {
+		count := 0
+		for _, entry := range inventory {
+			if entry.RiskTier == string(tier) {
+				count++
+			}
+		}
+		fmt.Fprintf(&report, "- %d %s secrets\n", count, tier)
+	}
+
+	report.WriteString("\n| Secret | Risk tier | Secret name | Scope | Repositories |\n")
+	report.WriteString("|--------|-----------|-------------|-------|--------------|\n")
+	for _, entry := range inventory {
+		var rows []SecretInventoryDeployment
+		repositories := map[SecretInventoryDeployment][]string{}
+		for _, deployment := range entry.Deployments {
+			row := SecretInventoryDeployment{Scope: deployment.Scope, SecretName: deployment.SecretName}
+			if repositories[row] == nil {
+				rows = append(rows, row)
+			}
+			repositories[row] = append(repositories[row], deployment.Repository)
+		}
+		riskTier := entry.RiskTier
+		if entry.Revoked {
+			riskTier += ", revoked"
+		}
+		for _, row := range rows {
+			fmt.Fprintf(&report, "| `%s` | %s | `%s` | %s | %s |\n", entry.Key, riskTier, row.SecretName, row.Scope, strings.Join(repositories[row], ", "))
+		}
+	}
+
+	return report.String()
+}
+
+func secretInventoryCSV(inventory []SecretInventoryEntry) (string, error) {
+	var content strings.Builder
+	writer := csv.NewWriter(&content)
+	if err := writer.Write([]string{"key", "risk_tier", "repository", "scope", "secret_name"}); err != nil {
+		return "", err
+	}
+	for _, entry := range inventory {
+		for _, deployment := range entry.Deployments {
+			if err := writer.Write([]string{entry.Key, entry.RiskTier, deployment.Repository, deployment.Scope, deployment.SecretName}); err != nil {
+				return "", err
+			}
+		}
+	}
+	writer.Flush()
+
+	return content.String(), writer.Error()
+}
+
+// SecretInventoryCommand is the command that writes the secret inventory of each stack to JSON,
+// Markdown and CSV files, run with `go run . secret-inventory [-output <directory>] [stack...]`.
+const SecretInventoryCommand = "secret-inventory"
+
+// runSecretInventory declares the organization against mocked resources with each stack's
+// configuration, without decrypting it, and writes secret-inventory-<stack>.json, .md and .csv.
+func runSecretInventory(args []string, declare pulumi.RunFunc) error {
+	flags := flag.NewFlagSet(SecretInventoryCommand, flag.ContinueOnError)
+	output := flags.String("output", ".", "the directory to write the inventory to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	stacks, err := stackNames(flags.Args())
+	if err != nil {
+		return err
+	}
+	project, err := projectName()
+	if err != nil {
+		return err
+	}
+
+	for _, stack := range stacks {
+		stackConfig, err := readStackConfig(project, stack)
+		if err != nil {
+			return err
+		}
+		state, _, err := declareWithMocks(project, stack, stackConfig.values, declare)
+		if err != nil {
+			return fmt.Errorf("declaring the %s stack: %w", stack, err)
+		}
+		inventory := secretInventory(state.secretDeployments)
+
+		jsonContent, err := json.MarshalIndent(inventory, "", "  ")
+		if err != nil {
+			return err
+		}
+		csvContent, err := secretInventoryCSV(inventory)
+		if err != nil {
+			return err
+		}
+		files := map[string]string{
+			"json": string(jsonContent) + "\n",
+			"md":   secretInventoryMarkdown(inventory),
+			"csv":  csvContent,
+		}
+		for extension, content := range files {
+			path := filepath.Join(*output, fmt.Sprintf("secret-inventory-%s.%s", stack, extension))
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return err
+			}
+			fmt.Println("wrote", path)
+		}
+	}
+
+	return nil
+}
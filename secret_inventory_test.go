@@ -0,0 +1,199 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// testSecretDeployments are deployments as they are recorded in the program state, out of order.
+var testSecretDeployments = []SecretDeployment{
+	{Repository: "wind-tunnel", Scope: SecretScopeActions, SecretName: "CACHIX_AUTH_TOKEN", Key: "holochain:cachixAuthToken"},
+	{Repository: "tx5", Scope: SecretScopeActions, SecretName: "HRA2_GITHUB_TOKEN", Key: "holochain:hra2GithubUserToken"},
+	{Repository: "holonix", Scope: SecretScopeActions, SecretName: "CACHIX_AUTH_TOKEN", Key: "holochain:cachixAuthToken"},
+	{Repository: "hc-github-config", Scope: SecretScopeActions, SecretName: "HRA2_GITHUB_TOKEN", Key: "holochain:hra2GithubAdminToken"},
+	{Repository: "hc-github-config", Scope: SecretScopeDependabot, SecretName: "HRA2_GITHUB_TOKEN", Key: "holochain:hra2GithubAdminToken"},
+	{Repository: "lair", Scope: SecretScopeActions, SecretName: "HRA2_GITHUB_TOKEN", Key: "holochain:hra2GithubUserToken"},
+	{Repository: "wind-tunnel", Scope: SecretScopeActions, SecretName: "NOMAD_ACCESS_TOKEN", Key: "wind-tunnel:nomadAccessToken", Revoked: true},
+}
+
+func TestSecretInventory(t *testing.T) {
+	tests := []struct {
+		name        string
+		deployments []SecretDeployment
+		want        []SecretInventoryEntry
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:        "grouped by secret, most dangerous first",
+			deployments: testSecretDeployments,
+			want: []SecretInventoryEntry{
+				{
+					Key:          "holochain:hra2GithubAdminToken",
+					RiskTier:     "critical",
+					Repositories: []string{"hc-github-config"},
+					Deployments: []SecretInventoryDeployment{
+						{Repository: "hc-github-config", Scope: "actions", SecretName: "HRA2_GITHUB_TOKEN"},
+						{Repository: "hc-github-config", Scope: "dependabot", SecretName: "HRA2_GITHUB_TOKEN"},
+					},
+				},
+				{
+					Key:          "holochain:hra2GithubUserToken",
+					RiskTier:     "high",
+					Repositories: []string{"lair", "tx5"},
+					Deployments: []SecretInventoryDeployment{
+						{Repository: "lair", Scope: "actions", SecretName: "HRA2_GITHUB_TOKEN"},
+						{Repository: "tx5", Scope: "actions", SecretName: "HRA2_GITHUB_TOKEN"},
+					},
+				},
+				{
+					Key:          "wind-tunnel:nomadAccessToken",
+					RiskTier:     "high",
+					Revoked:      true,
+					Repositories: []string{"wind-tunnel"},
+					Deployments: []SecretInventoryDeployment{
+						{Repository: "wind-tunnel", Scope: "actions", SecretName: "NOMAD_ACCESS_TOKEN"},
+					},
+				},
+				{
+					Key:          "holochain:cachixAuthToken",
+					RiskTier:     "standard",
+					Repositories: []string{"holonix", "wind-tunnel"},
+					Deployments: []SecretInventoryDeployment{
+						{Repository: "holonix", Scope: "actions", SecretName: "CACHIX_AUTH_TOKEN"},
+						{Repository: "wind-tunnel", Scope: "actions", SecretName: "CACHIX_AUTH_TOKEN"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if inventory := secretInventory(test.deployments); !reflect.DeepEqual(inventory, test.want) {
+				t.Errorf("secretInventory() = %+v, want %+v", inventory, test.want)
+			}
+		})
+	}
+}
+
+func TestSecretInventoryMarkdown(t *testing.T) {
+	tests := []struct {
+		name        string
+		deployments []SecretDeployment
+		want        string
+	}{
+		{
+			name: "empty",
+			want: `# Secret inventory
+
+- 0 critical secrets
+- 0 high secrets
+- 0 standard secrets
+
+| Secret | Risk tier | Secret name | Scope | Repositories |
+|--------|-----------|-------------|-------|--------------|
+`,
+		},
+		{
+			name:        "one row per secret name and scope",
+			deployments: testSecretDeployments,
+			want: "# Secret inventory\n\n" +
+				"- 1 critical secrets\n" +
+				"- 2 high secrets\n" +
+				"- 1 standard secrets\n\n" +
+				"| Secret | Risk tier | Secret name | Scope | Repositories |\n" +
+				"|--------|-----------|-------------|-------|--------------|\n" +
+				"| `holochain:hra2GithubAdminToken` | critical | `HRA2_GITHUB_TOKEN` | actions | hc-github-config |\n" +
+				"| `holochain:hra2GithubAdminToken` | critical | `HRA2_GITHUB_TOKEN` | dependabot | hc-github-config |\n" +
+				"| `holochain:hra2GithubUserToken` | high | `HRA2_GITHUB_TOKEN` | actions | lair, tx5 |\n" +
+				"| `wind-tunnel:nomadAccessToken` | high, revoked | `NOMAD_ACCESS_TOKEN` | actions | wind-tunnel |\n" +
+				"| `holochain:cachixAuthToken` | standard | `CACHIX_AUTH_TOKEN` | actions | holonix, wind-tunnel |\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if report := secretInventoryMarkdown(secretInventory(test.deployments)); report != test.want {
+				t.Errorf("secretInventoryMarkdown() =\n%s\nwant\n%s", report, test.want)
+			}
+		})
+	}
+}
+
+func TestSecretInventoryCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		deployments []SecretDeployment
+		want        string
+	}{
+		{
+			name: "empty",
+			want: "key,risk_tier,repository,scope,secret_name\n",
+		},
+		{
+			name:        "one row per deployment",
+			deployments: testSecretDeployments,
+			want: `key,risk_tier,repository,scope,secret_name
+holochain:hra2GithubAdminToken,critical,hc-github-config,actions,HRA2_GITHUB_TOKEN
+holochain:hra2GithubAdminToken,critical,hc-github-config,dependabot,HRA2_GITHUB_TOKEN
+holochain:hra2GithubUserToken,high,lair,actions,HRA2_GITHUB_TOKEN
+holochain:hra2GithubUserToken,high,tx5,actions,HRA2_GITHUB_TOKEN
+wind-tunnel:nomadAccessToken,high,wind-tunnel,actions,NOMAD_ACCESS_TOKEN
+holochain:cachixAuthToken,standard,holonix,actions,CACHIX_AUTH_TOKEN
+holochain:cachixAuthToken,standard,wind-tunnel,actions,CACHIX_AUTH_TOKEN
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content, err := secretInventoryCSV(secretInventory(test.deployments))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if content != test.want {
+				t.Errorf("secretInventoryCSV() =\n%s\nwant\n%s", content, test.want)
+			}
+		})
+	}
+}
+
+func TestDeploySecretConflictingKeys(t *testing.T) {
+	configuration := map[string]string{
+		"holochain:cachixAuthToken":     "cachix",
+		"holochain:hra2GithubUserToken": "user",
+	}
+	state, _, err := declareWithMocks("holochain", "github", configuration, func(ctx *pulumi.Context) error {
+		for _, deployment := range []SecretDeployment{
+			{Repository: "holonix", Scope: SecretScopeActions, ResourceName: "holonix-cachix-auth-token", SecretName: "CACHIX_AUTH_TOKEN", Key: "cachixAuthToken"},
+			{Repository: "holonix", Scope: SecretScopeDependabot, ResourceName: "holonix-dependabot-cachix-auth-token", SecretName: "CACHIX_AUTH_TOKEN", Key: "hra2GithubUserToken"},
+			{Repository: "holonix", Scope: SecretScopeActions, ResourceName: "holonix-cachix-auth-token-2", SecretName: "CACHIX_AUTH_TOKEN", Key: "hra2GithubUserToken"},
+		} {
+			if err := deploySecret(ctx, deployment); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, deployment := range state.secretDeployments {
+		keys = append(keys, string(deployment.Scope)+" "+deployment.Key)
+	}
+	if expected := []string{"actions holochain:cachixAuthToken", "dependabot holochain:hra2GithubUserToken"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected the deployments %v, got %v", expected, keys)
+	}
+	expected := ValidationErrors{
+		{Repository: "holonix", Problem: "the actions secret CACHIX_AUTH_TOKEN is given both holochain:cachixAuthToken and holochain:hra2GithubUserToken"},
+	}
+	if !reflect.DeepEqual(state.validationErrors, expected) {
+		t.Errorf("expected the problems %v, got %v", expected, state.validationErrors)
+	}
+}
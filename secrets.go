@@ -0,0 +1,418 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"gopkg.in/yaml.v3"
+)
+
+// PreflightSecretsCommand is the command that checks the secrets in each stack's configuration
+// against the secrets the program requires, run with `go run . preflight-secrets [stack...]`.
+const PreflightSecretsCommand = "preflight-secrets"
+
+// providerNamespaces are configuration namespaces read by providers rather than by the program.
+var providerNamespaces = []string{"github", "pulumi"}
+
+// requiredSecret is a secret read by requireSecret, or a plain value read by requireConfig, with its
+// namespace, for a repository.
+type requiredSecret struct {
+	// Key is the full configuration key, for example `wind-tunnel:nomadAccessToken`.
+	Key        string
+	Repository string
+	// Plain is set for values read by requireConfig, which don't have to be encrypted.
+	Plain bool
+}
+
+// configKey returns the full configuration key of key in a namespace, which is the project's if it is
+// empty, like config.New.
+func configKey(ctx *pulumi.Context, namespace string, key string) string {
+	if namespace == "" {
+		namespace = ctx.Project()
+	}
+
+	return namespace + ":" + key
+}
+
+// stackConfig is the configuration of a stack, read from Pulumi.yaml and Pulumi.<stack>.yaml.
+type stackConfig struct {
+	// values are the plain values, as the Pulumi engine passes them to the program.
+	values map[string]string
+	// secrets are the keys of the encrypted values.
+	secrets []string
+}
+
+// readStackConfig reads the configuration of a stack without decrypting it. Secrets are given a
+// placeholder value, which is enough to declare the resources that use them.
+func readStackConfig(project string, stack string) (stackConfig, error) {
+	stackConfig := stackConfig{values: map[string]string{}}
+
+	var projectFile struct {
+		Config map[string]any `yaml:"config"`
+	}
+	if err := readYAML("Pulumi.yaml", &projectFile); err != nil {
+		return stackConfig, err
+	}
+	var stackFile struct {
+		Config map[string]any `yaml:"config"`
+	}
+	if err := readYAML(fmt.Sprintf("Pulumi.%s.yaml", stack), &stackFile); err != nil {
+		return stackConfig, err
+	}
+
+	for _, file := range []map[string]any{projectFile.Config, stackFile.Config} {
+		for key, value := range file {
+			if !strings.Contains(key, ":") {
+				key = project + ":" + key
+			}
+			if object, ok := value.(map[string]any); ok {
+				if _, ok := object["secure"]; ok {
+					stackConfig.values[key] = "[secret]"
+					stackConfig.secrets = append(stackConfig.secrets, key)
+					continue
+				}
+				// Pulumi.yaml declares a key with its type, and either a value or a default.
+				if projectValue, ok := object["value"]; ok {
+					value = projectValue
+				} else if defaultValue, ok := object["default"]; ok {
+					value = defaultValue
+				} else if _, ok := object["type"]; ok {
+					continue
+				}
+			}
+			if text, ok := value.(string); ok {
+				stackConfig.values[key] = text
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return stackConfig, fmt.Errorf("reading %s from the %s stack: %w", key, stack, err)
+			}
+			stackConfig.values[key] = string(encoded)
+		}
+	}
+	slices.Sort(stackConfig.secrets)
+
+	return stackConfig, nil
+}
+
+func readYAML(path string, output any) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(content, output); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// SecretsPreflight compares the secrets a stack requires with the secrets in its configuration.
+type SecretsPreflight struct {
+	Stack string
+	// Required are the repositories that need each required secret.
+	Required map[string][]string
+	// Missing are required secrets that are not in the stack's configuration.
+	Missing []string
+	// Unused are secrets in a namespace the program reads that no repository requires.
+	Unused []string
+	// Orphaned are secrets in a namespace that neither the program nor a provider reads.
+	Orphaned []string
+}
+
+// preflightSecrets declares the organization with the stack's configuration, against mocked
+// resources, and compares the secrets that were read with the secrets in the configuration.
+func preflightSecrets(project string, stack string, declare pulumi.RunFunc) (SecretsPreflight, error) {
+	preflight := SecretsPreflight{Stack: stack, Required: map[string][]string{}}
+
+	stackConfig, err := readStackConfig(project, stack)
+	if err != nil {
+		return preflight, err
+	}
+	state, _, err := declareWithMocks(project, stack, stackConfig.values, declare)
+	if err != nil {
+		return preflight, fmt.Errorf("declaring the %s stack: %w", stack, err)
+	}
+
+	namespaces := slices.Clone(providerNamespaces)
+	namespaces = append(namespaces, project)
+	plain := map[string]bool{}
+	for _, required := range state.requiredSecrets {
+		plain[required.Key] = required.Plain
+		if !slices.Contains(preflight.Required[required.Key], required.Repository) {
+			preflight.Required[required.Key] = append(preflight.Required[required.Key], required.Repository)
+		}
+		namespace, _, _ := strings.Cut(required.Key, ":")
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	for key := range preflight.Required {
+		present := slices.Contains(stackConfig.secrets, key)
+		if plain[key] {
+			_, present = stackConfig.values[key]
+		}
+		if !present {
+			preflight.Missing = append(preflight.Missing, key)
+		}
+	}
+	slices.Sort(preflight.Missing)
+	for _, key := range stackConfig.secrets {
+		namespace, _, _ := strings.Cut(key, ":")
+		switch {
+		case !slices.Contains(namespaces, namespace):
+			preflight.Orphaned = append(preflight.Orphaned, key)
+		case slices.Contains(providerNamespaces, namespace):
+		case preflight.Required[key] == nil:
+			preflight.Unused = append(preflight.Unused, key)
+		}
+	}
+
+	return preflight, nil
+}
+
+// runPreflightSecrets checks the secrets of the named stacks, or of every stack with a
+// Pulumi.<stack>.yaml file, and fails if any required secret is missing.
+func runPreflightSecrets(args []string, declare pulumi.RunFunc) error {
+	stacks, err := stackNames(args)
+	if err != nil {
+		return err
+	}
+	project, err := projectName()
+	if err != nil {
+		return err
+	}
+
+	missing := 0
+	for _, stack := range stacks {
+		preflight, err := preflightSecrets(project, stack, declare)
+		if err != nil {
+			return err
+		}
+		fmt.Print(preflight.String())
+		missing += len(preflight.Missing)
+	}
+	if missing > 0 {
+		return fmt.Errorf("%d required secrets are missing", missing)
+	}
+
+	return nil
+}
+
+// stackNames returns the stacks named in args, or every stack with a Pulumi.<stack>.yaml file.
+func stackNames(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	files, err := filepath.Glob("Pulumi.*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	var stacks []string
+	for _, file := range files {
+		stacks = append(stacks, strings.TrimSuffix(strings.TrimPrefix(file, "Pulumi."), ".yaml"))
+	}
+
+	return stacks, nil
+}
+
+func projectName() (string, error) {
+	var project struct {
+		Name string `yaml:"name"`
+	}
+	if err := readYAML("Pulumi.yaml", &project); err != nil {
+		return "", err
+	}
+
+	return project.Name, nil
+}
+
+func (preflight SecretsPreflight) String() string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "Stack %s requires %d secrets.\n", preflight.Stack, len(preflight.Required))
+	sections := []struct {
+		title string
+		keys  []string
+	}{
+		{"Missing, required but not in Pulumi." + preflight.Stack + ".yaml", preflight.Missing},
+		{"Unused, not required by any repository", preflight.Unused},
+		{"Orphaned, in a namespace that nothing reads", preflight.Orphaned},
+	}
+	for _, section := range sections {
+		if len(section.keys) == 0 {
+			continue
+		}
+		fmt.Fprintf(&report, "\n%s:\n", section.title)
+		for _, key := range section.keys {
+			if repositories := preflight.Required[key]; repositories != nil {
+				fmt.Fprintf(&report, "  - %s (%s)\n", key, strings.Join(repositories, ", "))
+			} else {
+				fmt.Fprintf(&report, "  - %s\n", key)
+			}
+		}
+	}
+	report.WriteString("\n")
+
+	return report.String()
+}
+
+// UnusedSecretsCommand is the command that lists the secrets that can be retired, run with
+// `go run . unused-secrets [stack...]`.
+const UnusedSecretsCommand = "unused-secrets"
+
+// secretHelper is a function that reads secrets with requireSecret or deploySecret, or other
+// configuration with requireConfig.
+type secretHelper struct {
+	Name string
+	// Keys are the configuration keys the helper reads, without their namespace, which is chosen by
+	// the caller.
+	Keys []string
+}
+
+// addKey adds the configuration key in expression if it is a string literal.
+func (helper *secretHelper) addKey(expression ast.Expr) {
+	if key, ok := expression.(*ast.BasicLit); ok && key.Kind == token.STRING {
+		unquoted, _ := strconv.Unquote(key.Value)
+		helper.Keys = append(helper.Keys, unquoted)
+	}
+}
+
+// uncalledSecretHelpers parses the program's source in dir and returns the secret helpers that
+// cannot be reached from main. Methods and package-level variables are assumed to be reachable.
+func uncalledSecretHelpers(dir string) ([]secretHelper, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fileSet := token.NewFileSet()
+	functions := map[string]*ast.FuncDecl{}
+	var roots []ast.Node
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fileSet, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, declaration := range parsed.Decls {
+			function, ok := declaration.(*ast.FuncDecl)
+			switch {
+			case !ok:
+				roots = append(roots, declaration)
+			case function.Recv != nil || function.Name.Name == "main" || function.Name.Name == "init":
+				roots = append(roots, function)
+			default:
+				functions[function.Name.Name] = function
+			}
+		}
+	}
+
+	reachable := map[string]bool{}
+	var visit func(node ast.Node)
+	visit = func(node ast.Node) {
+		ast.Inspect(node, func(node ast.Node) bool {
+			if ident, ok := node.(*ast.Ident); ok && !reachable[ident.Name] {
+				if function, ok := functions[ident.Name]; ok {
+					reachable[ident.Name] = true
+					visit(function.Body)
+				}
+			}
+			return true
+		})
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+
+	var uncalled []secretHelper
+	for name, function := range functions {
+		if reachable[name] || function.Body == nil {
+			continue
+		}
+		helper := secretHelper{Name: name}
+		ast.Inspect(function.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				if ident, ok := node.Fun.(*ast.Ident); ok && (ident.Name == "requireSecret" || ident.Name == "requireConfig") && len(node.Args) == 4 {
+					helper.addKey(node.Args[3])
+				}
+			case *ast.CompositeLit:
+				if ident, ok := node.Type.(*ast.Ident); ok && ident.Name == "SecretDeployment" {
+					for _, element := range node.Elts {
+						if field, ok := element.(*ast.KeyValueExpr); ok {
+							if key, ok := field.Key.(*ast.Ident); ok && key.Name == "Key" {
+								helper.addKey(field.Value)
+							}
+						}
+					}
+				}
+			}
+			return true
+		})
+		if helper.Keys != nil {
+			uncalled = append(uncalled, helper)
+		}
+	}
+	slices.SortFunc(uncalled, func(a, b secretHelper) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return uncalled, nil
+}
+
+// runUnusedSecrets lists the secrets in the named stacks, or in every stack, that no repository
+// consumes, and the secret helpers that are never called, so that the credentials can be retired.
+func runUnusedSecrets(args []string, declare pulumi.RunFunc) error {
+	stacks, err := stackNames(args)
+	if err != nil {
+		return err
+	}
+	project, err := projectName()
+	if err != nil {
+		return err
+	}
+
+	for _, stack := range stacks {
+		preflight, err := preflightSecrets(project, stack, declare)
+		if err != nil {
+			return err
+		}
+		unused := append(slices.Clone(preflight.Unused), preflight.Orphaned...)
+		fmt.Printf("Secrets in Pulumi.%s.yaml that no repository consumes:\n", stack)
+		if len(unused) == 0 {
+			fmt.Println("  none")
+		}
+		for _, key := range unused {
+			fmt.Printf("  - %s\n", key)
+		}
+		fmt.Println()
+	}
+
+	uncalled, err := uncalledSecretHelpers(".")
+	if err != nil {
+		return err
+	}
+	fmt.Println("Secret helpers that are never called:")
+	if len(uncalled) == 0 {
+		fmt.Println("  none")
+	}
+	for _, helper := range uncalled {
+		fmt.Printf("  - %s (%s)\n", helper.Name, strings.Join(helper.Keys, ", "))
+	}
+
+	return nil
+}
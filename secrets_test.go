@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestReadStackConfig(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Pulumi.yaml": `name: holochain
+config:
+  github:owner:
+    value: holochain
+  tombstoneRevokedSecrets:
+    type: boolean
+    default: false
+  retiredRepositories:
+    type: array
+  staleAfterDays:
+    type: integer
+    default: 30
+`,
+		"Pulumi.github.yaml": `config:
+  holochain:staleAfterDays: "90"
+  holochain:hra2GithubUserToken:
+    secure: AAABAO
+  wind-tunnel:nomadAccessToken:
+    secure: AAABAP
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	stackConfig, err := readStackConfig("holochain", "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]string{
+		"github:owner":                      "holochain",
+		"holochain:tombstoneRevokedSecrets": "false",
+		"holochain:staleAfterDays":          "90",
+		"holochain:hra2GithubUserToken":     "[secret]",
+		"wind-tunnel:nomadAccessToken":      "[secret]",
+	}
+	if !reflect.DeepEqual(stackConfig.values, values) {
+		t.Errorf("values are %v, want %v", stackConfig.values, values)
+	}
+	secrets := []string{"holochain:hra2GithubUserToken", "wind-tunnel:nomadAccessToken"}
+	if !reflect.DeepEqual(stackConfig.secrets, secrets) {
+		t.Errorf("secrets are %v, want %v", stackConfig.secrets, secrets)
+	}
+}
+
+func TestPreflightSecrets(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Pulumi.yaml": "name: holochain\n",
+		"Pulumi.github.yaml": `config:
+  holochain:cachixAuthToken:
+    secure: AAABAO
+  holochain:retiredToken:
+    secure: AAABAP
+  other:token:
+    secure: AAABAQ
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	preflight, err := preflightSecrets("holochain", "github", func(ctx *pulumi.Context) error {
+		requireSecret(ctx, "", "holonix", "cachixAuthToken")
+		requireSecret(ctx, "wind-tunnel", "wind-tunnel", "nomadAccessToken")
+		requireConfig(ctx, "", "hc-github-config", "githubAppId")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := SecretsPreflight{
+		Stack: "github",
+		Required: map[string][]string{
+			"holochain:cachixAuthToken":    {"holonix"},
+			"holochain:githubAppId":        {"hc-github-config"},
+			"wind-tunnel:nomadAccessToken": {"wind-tunnel"},
+		},
+		Missing:  []string{"holochain:githubAppId", "wind-tunnel:nomadAccessToken"},
+		Unused:   []string{"holochain:retiredToken"},
+		Orphaned: []string{"other:token"},
+	}
+	if !reflect.DeepEqual(preflight, want) {
+		t.Errorf("preflightSecrets() = %+v, want %+v", preflight, want)
+	}
+}
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+//go:embed files/CONTRIBUTING.md
+var contributingMdContent string
+
+//go:embed files/AI_POLICY.md
+var aiPolicyMdContent string
+
+//go:embed files/CODEOWNERS.tmpl
+var codeOwnersContent string
+
+//go:embed files/dependabot.yml.tmpl
+var dependabotYmlContent string
+
+//go:embed files/SECURITY.md
+var securityMdContent string
+
+//go:embed files/ISSUE_TEMPLATE/bug_report.yml
+var bugReportIssueTemplateContent string
+
+//go:embed files/ISSUE_TEMPLATE/feature_request.yml
+var featureRequestIssueTemplateContent string
+
+//go:embed files/ISSUE_TEMPLATE/config.yml
+var issueTemplateConfigContent string
+
+//go:embed files/pull_request_template.md
+var pullRequestTemplateContent string
+
+var (
+	contributingMdTemplate = parseSharedFileTemplate("CONTRIBUTING.md", contributingMdContent)
+	aiPolicyMdTemplate     = parseSharedFileTemplate("AI_POLICY.md", aiPolicyMdContent)
+	codeOwnersTemplate     = parseSharedFileTemplate("CODEOWNERS", codeOwnersContent)
+	dependabotYmlTemplate  = parseSharedFileTemplate("dependabot.yml", dependabotYmlContent)
+	securityMdTemplate     = parseSharedFileTemplate("SECURITY.md", securityMdContent)
+	bugReportTemplate      = parseSharedFileTemplate("bug_report.yml", bugReportIssueTemplateContent)
+	featureRequestTemplate = parseSharedFileTemplate("feature_request.yml", featureRequestIssueTemplateContent)
+	issueTemplateConfig    = parseSharedFileTemplate("ISSUE_TEMPLATE/config.yml", issueTemplateConfigContent)
+	pullRequestTemplate    = parseSharedFileTemplate("pull_request_template.md", pullRequestTemplateContent)
+)
+
+// SharedFile is a file whose content is owned by this repository and pushed to
+// managed repositories.
+type SharedFile struct {
+	// Path of the file relative to the repository root.
+	Path string
+	// Content is the fully rendered content of the file.
+	Content string
+}
+
+// SharedFilesConfig selects the shared files that are distributed to a repository.
+//
+// SECURITY.md, the issue templates and the pull request template are distributed to every
+// repository unless they are turned off, so that all repositories present the same contributor
+// experience.
+type SharedFilesConfig struct {
+	// Repository describes the repository to the templates.
+	Repository RepositoryMetadata
+	// ContributingGuide adds CONTRIBUTING.md and AI_POLICY.md.
+	ContributingGuide bool
+	// CodeOwners adds .github/CODEOWNERS when set.
+	CodeOwners *CodeOwnersConfig
+	// Dependabot adds .github/dependabot.yml when set.
+	Dependabot *DependabotConfig
+	// NoSecurityPolicy skips SECURITY.md.
+	NoSecurityPolicy bool
+	// NoBugReport skips the bug report form, .github/ISSUE_TEMPLATE/bug_report.yml.
+	NoBugReport bool
+	// NoFeatureRequest skips the feature request form, .github/ISSUE_TEMPLATE/feature_request.yml.
+	NoFeatureRequest bool
+	// NoIssueTemplateConfig skips .github/ISSUE_TEMPLATE/config.yml, which lists the contact links and
+	// turns off blank issues, so that issues can only be opened with a form.
+	NoIssueTemplateConfig bool
+	// NoPullRequestTemplate skips .github/pull_request_template.md.
+	NoPullRequestTemplate bool
+	// Workflows are managed workflows added to .github/workflows/.
+	Workflows []Workflow
+	// AutoMerge labels the update PR, requests a review from the owning team and enables auto-merge,
+	// so that the PR is merged once it is approved and `ci_pass` is green. Update PRs that it
+	// supersedes are closed.
+	AutoMerge bool
+}
+
+// CodeOwnersConfig customises the generated CODEOWNERS file.
+//
+// The owning team is assigned the workflows and the manifests of each of the repository's
+// ecosystems. Rules are appended after those, and because the last matching pattern in a
+// CODEOWNERS file takes precedence, they can override the generated ownership.
+type CodeOwnersConfig struct {
+	Rules []CodeOwnersRule
+}
+
+// CodeOwnersRule assigns owners to the files matching a CODEOWNERS pattern.
+type CodeOwnersRule struct {
+	// Pattern uses the gitignore-like syntax of CODEOWNERS, for example `/src/`.
+	Pattern string
+	// Owners are users or teams, for example `@holochain/core-dev`.
+	Owners []string
+}
+
+func (config CodeOwnersConfig) validate() error {
+	for _, rule := range config.Rules {
+		if rule.Pattern == "" || strings.ContainsAny(rule.Pattern, " \t\n") {
+			return fmt.Errorf("invalid CODEOWNERS pattern %q", rule.Pattern)
+		}
+		if len(rule.Owners) == 0 {
+			return fmt.Errorf("CODEOWNERS pattern %q has no owners", rule.Pattern)
+		}
+		for _, owner := range rule.Owners {
+			if !strings.Contains(owner, "@") {
+				return fmt.Errorf("CODEOWNERS owner %q for pattern %q must be a @user, @org/team or an email address", owner, rule.Pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddSharedFiles renders the shared files selected by config and syncs them to the
+// repository with SyncSharedFiles. Nothing is synced to archived repositories.
+func AddSharedFiles(ctx *pulumi.Context, name string, repository *github.Repository, config SharedFilesConfig) error {
+	if isRetired(name) {
+		return nil
+	}
+
+	files, err := RenderSharedFiles(name, config)
+	if err != nil {
+		invalid(ctx, name, "%v", err)
+		return nil
+	}
+
+	metadata := config.Repository.withDefaults(name)
+	pullRequest, err := SyncSharedFiles(ctx, name, repository, metadata.DefaultBranch, files...)
+	if err != nil || pullRequest == nil || !config.AutoMerge {
+		return err
+	}
+
+	if err := AddRepositoryLabels(ctx, name, repository, SharedFilesLabel); err != nil {
+		return err
+	}
+
+	autoMergeSharedFilesPullRequest(ctx, name, metadata.OwningTeams, pullRequest)
+
+	return nil
+}
+
+// RenderSharedFiles returns the shared files selected by config, rendered for the named repository.
+func RenderSharedFiles(name string, config SharedFilesConfig) ([]SharedFile, error) {
+	data := SharedFileData{
+		RepositoryMetadata:      config.Repository.withDefaults(name),
+		ContributingGuide:       config.ContributingGuide,
+		CiPassCheck:             CiPassCheck,
+		ConventionalCommitTypes: conventionalCommitTypes,
+	}
+
+	var templates []sharedFileTemplate
+	if config.ContributingGuide {
+		templates = append(templates,
+			sharedFileTemplate{path: "CONTRIBUTING.md", template: contributingMdTemplate},
+			sharedFileTemplate{path: "AI_POLICY.md", template: aiPolicyMdTemplate},
+		)
+	}
+	if config.CodeOwners != nil {
+		if err := config.CodeOwners.validate(); err != nil {
+			return nil, fmt.Errorf("invalid CODEOWNERS config for %s: %w", name, err)
+		}
+		data.CodeOwners = config.CodeOwners
+		templates = append(templates, sharedFileTemplate{path: ".github/CODEOWNERS", template: codeOwnersTemplate})
+	}
+	if config.Dependabot != nil {
+		dependabot, err := config.Dependabot.resolve()
+		if err != nil {
+			return nil, fmt.Errorf("invalid Dependabot config for %s: %w", name, err)
+		}
+		data.Dependabot = &dependabot
+		templates = append(templates, sharedFileTemplate{path: ".github/dependabot.yml", template: dependabotYmlTemplate})
+	}
+	if !config.NoSecurityPolicy {
+		templates = append(templates, sharedFileTemplate{path: "SECURITY.md", template: securityMdTemplate})
+	}
+	if !config.NoBugReport {
+		templates = append(templates, sharedFileTemplate{path: ".github/ISSUE_TEMPLATE/bug_report.yml", template: bugReportTemplate})
+	}
+	if !config.NoFeatureRequest {
+		templates = append(templates, sharedFileTemplate{path: ".github/ISSUE_TEMPLATE/feature_request.yml", template: featureRequestTemplate})
+	}
+	if !config.NoIssueTemplateConfig {
+		templates = append(templates, sharedFileTemplate{path: ".github/ISSUE_TEMPLATE/config.yml", template: issueTemplateConfig})
+	}
+	if !config.NoPullRequestTemplate {
+		templates = append(templates, sharedFileTemplate{path: ".github/pull_request_template.md", template: pullRequestTemplate})
+	}
+	for _, workflow := range config.Workflows {
+		workflowTemplate, ok := workflowTemplates[workflow]
+		if !ok {
+			return nil, fmt.Errorf("unknown workflow %q for %s", workflow, name)
+		}
+		templates = append(templates, workflowTemplate)
+	}
+
+	files := make([]SharedFile, 0, len(templates))
+	for _, sharedTemplate := range templates {
+		content, err := renderSharedFileTemplate(sharedTemplate.template, data)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(sharedTemplate.path, ".github/workflows/") {
+			if err := verifyPinnedActions(sharedTemplate.path, content); err != nil {
+				return nil, fmt.Errorf("rendering workflows for %s: %w", name, err)
+			}
+		}
+		files = append(files, SharedFile{Path: sharedTemplate.path, Content: content})
+	}
+
+	return files, nil
+}
+
+// sharedFilesBranch is the branch that shared file updates are committed to.
+const sharedFilesBranch = "chore/update-shared-files"
+
+// legacySharedFilesBranches were used for one PR per shared file, before all of the files were
+// synced through sharedFilesBranch. Open PRs from these branches are superseded.
+var legacySharedFilesBranches = []string{
+	"chore/update-contributing-guide",
+	"chore/update-code-owners",
+	"chore/update-dependabot-yml",
+}
+
+// sharedFileTemplate pairs a template with the path it is rendered to.
+type sharedFileTemplate struct {
+	path     string
+	template *template.Template
+}
+
+// SyncSharedFiles commits the given files to a single branch and opens one pull request
+// against the base branch if any of the content has changed since the last deployment.
+// The pull request is nil if there are no files.
+func SyncSharedFiles(ctx *pulumi.Context, name string, repository *github.Repository, baseBranch string, files ...SharedFile) (*github.RepositoryPullRequest, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	contentHash := pulumi.String(sharedFilesHash(files))
+
+	// The branch and files are disposed of by merging the PR, so Pulumi must not delete them when the
+	// content hash triggers a replacement: the branch name is reused, so deleting the old resources
+	// would remove the branch behind the new PR, or fail on a stale blob SHA.
+	branch, err := github.NewBranch(ctx, fmt.Sprintf("%s-shared-files-branch", name), &github.BranchArgs{
+		Repository:   repository.Name,
+		Branch:       pulumi.String(sharedFilesBranch),
+		SourceBranch: pulumi.String(baseBranch),
+	}, pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true), InRepository(ctx, name))
+	if err != nil {
+		return nil, err
+	}
+
+	// All files are committed to the same branch; DependsOn serialises the commits so they
+	// don't race on the branch head.
+	var commits []pulumi.Resource
+	for _, sharedFile := range files {
+		file, err := github.NewRepositoryFile(ctx, fmt.Sprintf("%s-shared-file-%s", name, sharedFileResourceSuffix(sharedFile.Path)), &github.RepositoryFileArgs{
+			Repository:        repository.Name,
+			Branch:            branch.Branch,
+			File:              pulumi.String(sharedFile.Path),
+			Content:           pulumi.String(sharedFile.Content),
+			CommitMessage:     pulumi.String(fmt.Sprintf("chore: update %s with shared content", sharedFile.Path)),
+			CommitAuthor:      pulumi.String("holochain-release-automation2"),
+			CommitEmail:       pulumi.String("hra@holochain.org"),
+			OverwriteOnCreate: pulumi.Bool(true),
+		}, pulumi.DependsOn(commits), pulumi.ReplacementTrigger(contentHash), pulumi.RetainOnDelete(true), InRepository(ctx, name))
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, file)
+	}
+
+	pullRequest, err := github.NewRepositoryPullRequest(ctx, fmt.Sprintf("%s-shared-files-pr", name), &github.RepositoryPullRequestArgs{
+		BaseRepository: repository.Name,
+		BaseRef:        pulumi.String(baseBranch),
+		HeadRef:        branch.Branch,
+		Title:          pulumi.String("chore: update shared files"),
+		Body:           pulumi.String(sharedFilesPullRequestBody(files)),
+	}, pulumi.DependsOn(commits), pulumi.ReplacementTrigger(contentHash), pulumi.DeleteBeforeReplace(true), InRepository(ctx, name))
+	if err != nil {
+		return nil, err
+	}
+
+	trackSharedFilesDrift(ctx, name, baseBranch, files, pullRequest)
+
+	return pullRequest, nil
+}
+
+// sharedFilesHash hashes the paths and content of files so that a change to any of them,
+// or to the set of files, triggers a new branch and pull request.
+func sharedFilesHash(files []SharedFile) string {
+	hash := sha256.New()
+	for _, file := range files {
+		hash.Write([]byte(file.Path))
+		hash.Write([]byte{0})
+		hash.Write([]byte(file.Content))
+		hash.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+var nonResourceNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// sharedFileResourceSuffix turns a file path into a string that can be used in a resource name,
+// for example `.github/CODEOWNERS` becomes `github-codeowners`.
+func sharedFileResourceSuffix(path string) string {
+	return strings.Trim(nonResourceNameChars.ReplaceAllString(strings.ToLower(path), "-"), "-")
+}
+
+func sharedFilesPullRequestBody(files []SharedFile) string {
+	var body strings.Builder
+	body.WriteString("This PR updates the following files with the content from the shared files in the hc-github-config repo:\n\n")
+	for _, file := range files {
+		fmt.Fprintf(&body, "- `%s`\n", file.Path)
+	}
+
+	return body.String()
+}
+
+// autoMergeSharedFilesPullRequest labels the update PR, requests a review from the owning team,
+// enables auto-merge and closes the update PRs it supersedes. None of this is managed by the
+// Pulumi provider, so it is done through the GitHub API once the PR exists, and skipped during
+// previews. Failures are logged rather than failing the deployment, because the PR itself is
+// still usable and the next deployment will try again.
+//
+// The label is added last and marks a PR that has been set up, so that later deployments leave the
+// PR alone until the files change and a new PR replaces it, rather than requesting another review.
+func autoMergeSharedFilesPullRequest(ctx *pulumi.Context, name string, owningTeams []string, pullRequest *github.RepositoryPullRequest) {
+	if ctx.DryRun() {
+		return
+	}
+
+	pullRequest.Number.ApplyT(func(number int) int {
+		api, err := newGithubAPI(ctx)
+		if err == nil {
+			err = autoMergeSharedFilesPullRequestNumber(api, name, owningTeams, number)
+		}
+		if err != nil {
+			_ = ctx.Log.Warn(fmt.Sprintf("unable to set up auto-merge for shared files PR #%d on %s: %v", number, name, err), nil)
+		}
+
+		return number
+	})
+}
+
+func autoMergeSharedFilesPullRequestNumber(api *githubAPI, name string, owningTeams []string, number int) error {
+	pullRequest, err := api.pullRequest(name, number)
+	if err != nil {
+		return err
+	}
+	if pullRequest.State != "open" || pullRequest.hasLabel(string(SharedFilesLabel)) {
+		return nil
+	}
+
+	for _, head := range append([]string{sharedFilesBranch}, legacySharedFilesBranches...) {
+		open, err := api.openPullRequests(name, head)
+		if err != nil {
+			return err
+		}
+		for _, superseded := range open {
+			if superseded.Number == number {
+				continue
+			}
+			if err := api.comment(name, superseded.Number, fmt.Sprintf("Superseded by #%d.", number)); err != nil {
+				return err
+			}
+			if err := api.closePullRequest(name, superseded.Number); err != nil {
+				return err
+			}
+		}
+	}
+
+	var teams []string
+	for _, team := range owningTeams {
+		if !pullRequest.hasRequestedTeam(team) {
+			teams = append(teams, team)
+		}
+	}
+	if len(teams) > 0 {
+		if err := api.requestTeamReview(name, number, teams...); err != nil {
+			return err
+		}
+	}
+	// Auto-merge waits for the approval that the default ruleset requires, so the PR is merged once
+	// one of the owning teams approves it and ci_pass is green. Rebase is the only merge method allowed by
+	// StandardRepositoryArgs.
+	if pullRequest.AutoMerge == nil {
+		if err := api.enableAutoMerge(pullRequest, "REBASE"); err != nil {
+			return err
+		}
+	}
+
+	return api.addLabels(name, number, string(SharedFilesLabel))
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAutoMergeSharedFilesPullRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		pullRequest githubPullRequest
+		expected    []string
+	}{
+		{
+			name:        "new",
+			pullRequest: githubPullRequest{Number: 12, NodeID: "PR_12", State: "open"},
+			expected: []string{
+				"GET /repos/holochain/lair/pulls/12",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-shared-files",
+				"POST /repos/holochain/lair/issues/7/comments",
+				"PATCH /repos/holochain/lair/pulls/7",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-contributing-guide",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-code-owners",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-dependabot-yml",
+				"POST /repos/holochain/lair/pulls/12/requested_reviewers",
+				"POST /graphql",
+				"POST /repos/holochain/lair/issues/12/labels",
+			},
+		},
+		{
+			name: "review already requested and auto-merge enabled",
+			pullRequest: func() githubPullRequest {
+				pullRequest := githubPullRequest{Number: 12, NodeID: "PR_12", State: "open", RequestedTeams: []githubTeam{{Slug: "holochain-devs"}}}
+				pullRequest.AutoMerge = &struct {
+					MergeMethod string `json:"merge_method"`
+				}{MergeMethod: "rebase"}
+				return pullRequest
+			}(),
+			expected: []string{
+				"GET /repos/holochain/lair/pulls/12",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-shared-files",
+				"POST /repos/holochain/lair/issues/7/comments",
+				"PATCH /repos/holochain/lair/pulls/7",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-contributing-guide",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-code-owners",
+				"GET /repos/holochain/lair/pulls?state=open&per_page=100&head=holochain:chore/update-dependabot-yml",
+				"POST /repos/holochain/lair/issues/12/labels",
+			},
+		},
+		{
+			name:        "already set up",
+			pullRequest: githubPullRequest{Number: 12, NodeID: "PR_12", State: "open", Labels: []githubLabel{{Name: string(SharedFilesLabel)}}},
+			expected:    []string{"GET /repos/holochain/lair/pulls/12"},
+		},
+		{
+			name:        "merged",
+			pullRequest: githubPullRequest{Number: 12, NodeID: "PR_12", State: "closed"},
+			expected:    []string{"GET /repos/holochain/lair/pulls/12"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL))
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/holochain/lair/pulls/12":
+					_ = json.NewEncoder(w).Encode(test.pullRequest)
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.RawQuery, "head=holochain:chore/update-shared-files"):
+					_ = json.NewEncoder(w).Encode([]githubPullRequest{{Number: 7, State: "open"}, {Number: 12, State: "open"}})
+				case r.Method == http.MethodGet:
+					_, _ = w.Write([]byte("[]"))
+				default:
+					_, _ = w.Write([]byte("{}"))
+				}
+			}))
+			defer server.Close()
+			api := newGithubAPIWithToken("token")
+			api.baseURL = server.URL
+
+			if err := autoMergeSharedFilesPullRequestNumber(api, "lair", []string{"holochain-devs"}, 12); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(requests, test.expected) {
+				t.Errorf("expected requests\n%s\ngot\n%s", strings.Join(test.expected, "\n"), strings.Join(requests, "\n"))
+			}
+		})
+	}
+}
+
+func TestRenderSharedFilesIssueTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   SharedFilesConfig
+		expected []string
+	}{
+		{
+			name:     "all",
+			expected: []string{".github/ISSUE_TEMPLATE/bug_report.yml", ".github/ISSUE_TEMPLATE/feature_request.yml", ".github/ISSUE_TEMPLATE/config.yml"},
+		},
+		{
+			name:     "no bug report",
+			config:   SharedFilesConfig{NoBugReport: true},
+			expected: []string{".github/ISSUE_TEMPLATE/feature_request.yml", ".github/ISSUE_TEMPLATE/config.yml"},
+		},
+		{
+			name:     "no feature request",
+			config:   SharedFilesConfig{NoFeatureRequest: true},
+			expected: []string{".github/ISSUE_TEMPLATE/bug_report.yml", ".github/ISSUE_TEMPLATE/config.yml"},
+		},
+		{
+			name:     "no config",
+			config:   SharedFilesConfig{NoIssueTemplateConfig: true},
+			expected: []string{".github/ISSUE_TEMPLATE/bug_report.yml", ".github/ISSUE_TEMPLATE/feature_request.yml"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files, err := RenderSharedFiles("lair", test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var paths []string
+			for _, file := range files {
+				if strings.HasPrefix(file.Path, ".github/ISSUE_TEMPLATE/") {
+					paths = append(paths, file.Path)
+				}
+			}
+			if !slices.Equal(paths, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, paths)
+			}
+		})
+	}
+}
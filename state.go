@@ -0,0 +1,63 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// programState collects what the helpers declare across all repositories, so that reports
+// covering the whole organization can be exported once every repository has been declared.
+type programState struct {
+	// sharedFilesDrift holds one SharedFilesDrift output per repository that receives shared files.
+	sharedFilesDrift []pulumi.Output
+	// repositoryLabels holds the labels added to each repository, in the order the repositories were declared.
+	repositoryLabels []*repositoryLabels
+	// managedRepositories are the names of the repositories declared with StandardRepositoryArgs.
+	managedRepositories []string
+	// repositoryOverrides are the overrides of the standard repository settings, see RepositoryOverride.
+	repositoryOverrides []repositoryOverride
+	// repositoryComponents are the HolochainRepository components by repository name.
+	repositoryComponents map[string]*HolochainRepository
+	// retiringRepositories are the repositories that are not active, as applyRepositoryLifecycle read them from GitHub.
+	retiringRepositories map[string]*github.LookupRepositoryResult
+	// validationErrors are the problems found in the configuration, see invalid.
+	validationErrors ValidationErrors
+	// requiredSecrets are the secrets read with requireSecret, and the values read with requireConfig,
+	// in the order they were read.
+	requiredSecrets []requiredSecret
+	// secretDeployments are the secrets stored in repositories by deploySecret.
+	secretDeployments []SecretDeployment
+	// githubTokens are the GitHub token tiers given to each repository by AddGithubToken.
+	githubTokens map[string]GithubTokenConfig
+	// githubAppRepositories are the repositories that use the GitHub App, added by AddGithubApp.
+	githubAppRepositories []string
+	// oidcSubjectClaims are the OIDC subject claims chosen by each repository with AddOidcSubjectClaims.
+	oidcSubjectClaims map[string][]string
+	// actionsPermissions are the Actions permissions of repositories set with SetActionsPermissions.
+	actionsPermissions map[string]ActionsPermissionsConfig
+	// runnerGroupRepositories are the repositories added to each runner group with AddRunnerGroup.
+	runnerGroupRepositories map[RunnerGroup][]string
+	// validating is set for the runs made by declareWithMocks.
+	validating bool
+}
+
+var (
+	programStatesMutex sync.Mutex
+	programStates      = map[*pulumi.Context]*programState{}
+)
+
+// stateFor returns the state of the program run that ctx belongs to.
+func stateFor(ctx *pulumi.Context) *programState {
+	programStatesMutex.Lock()
+	defer programStatesMutex.Unlock()
+
+	state, ok := programStates[ctx]
+	if !ok {
+		state = &programState{repositoryComponents: map[string]*HolochainRepository{}, retiringRepositories: map[string]*github.LookupRepositoryResult{}, githubTokens: map[string]GithubTokenConfig{}, oidcSubjectClaims: map[string][]string{}, actionsPermissions: map[string]ActionsPermissionsConfig{}, runnerGroupRepositories: map[RunnerGroup][]string{}}
+		programStates[ctx] = state
+	}
+
+	return state
+}
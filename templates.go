@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Ecosystem is a language or packaging ecosystem used by a repository.
+type Ecosystem string
+
+const (
+	EcosystemRust Ecosystem = "rust"
+	EcosystemNpm  Ecosystem = "npm"
+	EcosystemGo   Ecosystem = "go"
+	EcosystemNix  Ecosystem = "nix"
+)
+
+// ReleaseScheme describes how a repository publishes releases.
+type ReleaseScheme string
+
+const (
+	// ReleaseSchemeNone is used by repositories that do not publish releases.
+	ReleaseSchemeNone ReleaseScheme = ""
+	// ReleaseSchemeReleaseIntegration publishes crates with the holochain_release_integration CLI.
+	ReleaseSchemeReleaseIntegration ReleaseScheme = "release-integration"
+	// ReleaseSchemeNpm publishes packages to npm.
+	ReleaseSchemeNpm ReleaseScheme = "npm"
+	// ReleaseSchemeGo publishes Go modules by tagging releases.
+	ReleaseSchemeGo ReleaseScheme = "go"
+)
+
+// RepositoryMetadata describes a repository to the shared file templates.
+//
+// Templates can refer to every field, for example `{{ .Name }}` or `{{ .OwningTeams }}`.
+// Referring to a field that does not exist is an error rather than an empty string.
+type RepositoryMetadata struct {
+	// Name of the repository, for example `holochain-wasmer`. Filled in by AddSharedFiles.
+	Name string
+	// Description of the repository, matching the description on GitHub.
+	Description string
+	// Ecosystems used by the repository.
+	Ecosystems []Ecosystem
+	// DefaultBranch of the repository, `main` if not set.
+	DefaultBranch string
+	// Homepage of the project, if it has one outside GitHub.
+	Homepage string
+	// ReleaseScheme used to publish the repository.
+	ReleaseScheme ReleaseScheme
+	// OwningTeams are the slugs of the GitHub teams that own the repository, `holochain-devs` if not
+	// set.
+	OwningTeams []string
+}
+
+// HasEcosystem reports whether the repository uses the named ecosystem. It can be used from
+// templates as `{{ if .HasEcosystem "rust" }}`.
+func (metadata RepositoryMetadata) HasEcosystem(ecosystem Ecosystem) bool {
+	return slices.Contains(metadata.Ecosystems, ecosystem)
+}
+
+// Owners returns the owning teams as CODEOWNERS owners, such as `@holochain/holochain-devs`. It can
+// be used from templates as `{{ .Owners }}`.
+func (metadata RepositoryMetadata) Owners() string {
+	owners := make([]string, 0, len(metadata.OwningTeams))
+	for _, team := range metadata.OwningTeams {
+		owners = append(owners, "@"+githubOrganization+"/"+team)
+	}
+
+	return strings.Join(owners, " ")
+}
+
+// withDefaults returns a copy of the metadata with the defaults applied to unset fields.
+func (metadata RepositoryMetadata) withDefaults(name string) RepositoryMetadata {
+	if metadata.Name == "" {
+		metadata.Name = name
+	}
+	if metadata.DefaultBranch == "" {
+		metadata.DefaultBranch = "main"
+	}
+	if len(metadata.OwningTeams) == 0 {
+		metadata.OwningTeams = []string{"holochain-devs"}
+	}
+
+	return metadata
+}
+
+// SharedFileData is the data that shared file templates are rendered with.
+type SharedFileData struct {
+	RepositoryMetadata
+	// ContributingGuide is set when CONTRIBUTING.md and AI_POLICY.md are distributed to the repository.
+	ContributingGuide bool
+	// CodeOwners is only set when rendering CODEOWNERS.
+	CodeOwners *CodeOwnersConfig
+	// Dependabot is only set when rendering dependabot.yml.
+	Dependabot *DependabotConfig
+	// CiPassCheck is the status check required by the repository rulesets.
+	CiPassCheck string
+	// ConventionalCommitTypes are the commit types that PR titles can use.
+	ConventionalCommitTypes []string
+}
+
+// sharedFileFuncs are the functions available to shared file templates in addition to the
+// text/template builtins.
+var sharedFileFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"quote": strconv.Quote,
+	"json": func(value any) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+	// action renders a `uses` reference to an action pinned in actionPins.
+	"action": pinnedAction,
+	// secret renders a reference to a GitHub secret, which is substituted by GitHub rather than by the template.
+	"secret": func(name string) string {
+		return fmt.Sprintf("${{secrets.%s}}", name)
+	},
+}
+
+// parseSharedFileTemplate parses a shared file template, panicking if it is invalid so that
+// a broken template stops the program before any resources are registered. A reference to an
+// undefined variable such as `{{ $name }}` is a parse error.
+func parseSharedFileTemplate(name string, content string) *template.Template {
+	return template.Must(template.New(name).Funcs(sharedFileFuncs).Parse(content))
+}
+
+// renderSharedFileTemplate renders a template with text/template semantics. Unlike html/template
+// no escaping is applied. The data is a struct rather than a map, so a reference to a field that
+// SharedFileData doesn't have, such as `{{ .Owner }}`, fails the render instead of rendering as
+// `<no value>`.
+func renderSharedFileTemplate(tmpl *template.Template, data SharedFileData) (string, error) {
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("rendering %s for %s: %w", tmpl.Name(), data.Name, err)
+	}
+
+	return rendered.String(), nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSharedFileTemplate(t *testing.T) {
+	data := SharedFileData{RepositoryMetadata: RepositoryMetadata{
+		Description: "Secure storage for keys & secrets",
+		Ecosystems:  []Ecosystem{EcosystemRust},
+	}.withDefaults("lair")}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		err      string
+	}{
+		{
+			name:     "fields",
+			content:  "{{ .Name }} on {{ .DefaultBranch }} is owned by {{ .Owners }}",
+			expected: "lair on main is owned by @holochain/holochain-devs",
+		},
+		{
+			name:     "no HTML escaping",
+			content:  "{{ .Description }}",
+			expected: "Secure storage for keys & secrets",
+		},
+		{
+			name:     "methods",
+			content:  `{{ if .HasEcosystem "rust" }}rust{{ end }}{{ if .HasEcosystem "npm" }}npm{{ end }}`,
+			expected: "rust",
+		},
+		{
+			name:    "unknown field",
+			content: "{{ .Owner }}",
+			err:     `rendering unknown field for lair: template: unknown field:1:3: executing "unknown field" at <.Owner>: can't evaluate field Owner in type main.SharedFileData`,
+		},
+		{
+			name:    "field of a missing config",
+			content: "{{ .Dependabot.Updates }}",
+			err:     "nil pointer evaluating *main.DependabotConfig.Updates",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rendered, err := renderSharedFileTemplate(parseSharedFileTemplate(test.name, test.content), data)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error containing %q, got %q, %v", test.err, rendered, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rendered != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, rendered)
+			}
+		})
+	}
+}
+
+func TestParseSharedFileTemplateUndefinedVariable(t *testing.T) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil || !strings.Contains(recovered.(error).Error(), "undefined variable") {
+			t.Errorf("expected an undefined variable panic, got %v", recovered)
+		}
+	}()
+	parseSharedFileTemplate("undefined variable", "{{ $owner }}")
+}
+
+func TestRepositoryMetadataOwners(t *testing.T) {
+	tests := []struct {
+		teams    []string
+		expected string
+	}{
+		{expected: "@holochain/holochain-devs"},
+		{teams: []string{"holochain-devs", "release-team"}, expected: "@holochain/holochain-devs @holochain/release-team"},
+	}
+	for _, test := range tests {
+		if owners := (RepositoryMetadata{OwningTeams: test.teams}).withDefaults("lair").Owners(); owners != test.expected {
+			t.Errorf("expected %q for %v, got %q", test.expected, test.teams, owners)
+		}
+	}
+}
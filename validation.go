@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// ValidationError is a problem with the desired configuration of a repository. Problems that
+// don't belong to a repository, such as an invalid files/labels.yml, have no repository.
+type ValidationError struct {
+	Repository string
+	Problem    string
+}
+
+func (validationError ValidationError) Error() string {
+	if validationError.Repository == "" {
+		return validationError.Problem
+	}
+
+	return fmt.Sprintf("%s: %s", validationError.Repository, validationError.Problem)
+}
+
+// ValidationErrors are every problem found in the desired configuration.
+type ValidationErrors []ValidationError
+
+func (validationErrors ValidationErrors) Error() string {
+	sorted := slices.Clone(validationErrors)
+	slices.SortStableFunc(sorted, func(a, b ValidationError) int {
+		return strings.Compare(a.Repository, b.Repository)
+	})
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "found %d problems in the configuration:", len(sorted))
+	for _, validationError := range sorted {
+		fmt.Fprintf(&message, "\n  - %s", validationError.Error())
+	}
+
+	return message.String()
+}
+
+// err returns the errors as an error, or nil if there are none.
+func (validationErrors ValidationErrors) err() error {
+	if len(validationErrors) == 0 {
+		return nil
+	}
+
+	return validationErrors
+}
+
+// invalid records a problem with the configuration of a repository. Helpers record problems
+// instead of returning them, and skip whatever is invalid, so that every problem is reported
+// together by validateOrganization.
+func invalid(ctx *pulumi.Context, repository string, format string, args ...any) {
+	validationError := ValidationError{Repository: repository, Problem: fmt.Sprintf(format, args...)}
+	state := stateFor(ctx)
+	if !slices.Contains(state.validationErrors, validationError) {
+		state.validationErrors = append(state.validationErrors, validationError)
+	}
+}
+
+// requireSecret reads a secret from a namespace of the configuration, the project's if it is empty,
+// recording a problem with the repository that needs it if it is missing. Every secret that is read
+// is recorded for the preflight-secrets command.
+func requireSecret(ctx *pulumi.Context, namespace string, repository string, key string) pulumi.StringOutput {
+	state := stateFor(ctx)
+	state.requiredSecrets = append(state.requiredSecrets, requiredSecret{Key: configKey(ctx, namespace, key), Repository: repository})
+
+	value, err := config.New(ctx, namespace).TrySecret(key)
+	if err != nil {
+		invalid(ctx, repository, "%v", err)
+		return pulumi.ToSecret(pulumi.String("")).(pulumi.StringOutput)
+	}
+
+	return value
+}
+
+// requireConfig reads a value from a namespace of the configuration, the project's if it is empty,
+// recording a problem with the repository that needs it if it is missing. Like secrets, every value
+// that is read is recorded for the preflight-secrets command.
+func requireConfig(ctx *pulumi.Context, namespace string, repository string, key string) string {
+	state := stateFor(ctx)
+	state.requiredSecrets = append(state.requiredSecrets, requiredSecret{Key: configKey(ctx, namespace, key), Repository: repository, Plain: true})
+
+	value, err := config.New(ctx, namespace).Try(key)
+	if err != nil {
+		invalid(ctx, repository, "%v", err)
+	}
+
+	return value
+}
+
+// validateOrganization checks the whole desired configuration before anything is registered with
+// the Pulumi engine. It runs declare against mocked resources, with the stack's configuration, and
+// returns every problem that the helpers recorded, along with resources that are declared more than
+// once.
+func validateOrganization(ctx *pulumi.Context, declare pulumi.RunFunc) error {
+	state, duplicates, err := declareWithMocks(ctx.Project(), ctx.Stack(), nil, declare)
+	if err != nil {
+		return fmt.Errorf("validating the configuration: %w", err)
+	}
+
+	return append(state.validationErrors, duplicates...).err()
+}
+
+// declareWithMocks runs declare against mocked resources, as a preview, and returns what the helpers
+// recorded along with the resources that were declared more than once. The configuration is read
+// from the environment, like in a Pulumi run, unless configuration is given.
+func declareWithMocks(project string, stack string, configuration map[string]string, declare pulumi.RunFunc) (*programState, ValidationErrors, error) {
+	mocks := &validationMocks{project: project, stack: stack, resources: map[resource.URN]bool{}}
+	var state *programState
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		state = stateFor(ctx)
+		state.validating = true
+		return declare(ctx)
+	}, pulumi.WithMocks(project, stack, mocks), func(info *pulumi.RunInfo) {
+		info.DryRun = true
+		if configuration != nil {
+			info.Config = configuration
+		}
+	})
+
+	return state, mocks.duplicates, err
+}
+
+// isValidating reports whether ctx belongs to a run made by declareWithMocks, which must not call the
+// GitHub API or log warnings that the real run logs again.
+func isValidating(ctx *pulumi.Context) bool {
+	return stateFor(ctx).validating
+}
+
+// validationMocks stand in for the Pulumi engine during validateOrganization, and find resources
+// that are declared more than once.
+type validationMocks struct {
+	project    string
+	stack      string
+	mutex      sync.Mutex
+	resources  map[resource.URN]bool
+	duplicates ValidationErrors
+}
+
+func (mocks *validationMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	// Resources are keyed by the URN that Pulumi gives them, which has the type of their parent but
+	// not its name, so two components can't have children with the same type and name.
+	parent := args.RegisterRPC.GetParent()
+	var parentType tokens.Type
+	if parent != "" {
+		parentType = resource.URN(parent).QualifiedType()
+	}
+	key := resource.NewURN(tokens.QName(mocks.stack), tokens.PackageName(mocks.project), parentType, tokens.Type(args.TypeToken), args.Name)
+
+	mocks.mutex.Lock()
+	defer mocks.mutex.Unlock()
+	if mocks.resources[key] {
+		repository := ""
+		if _, component, ok := strings.Cut(parent, HolochainRepositoryType+"::"); ok {
+			repository = component
+		}
+		mocks.duplicates = append(mocks.duplicates, ValidationError{
+			Repository: repository,
+			Problem:    fmt.Sprintf("the %s resource %q is declared more than once", args.TypeToken, args.Name),
+		})
+	}
+	mocks.resources[key] = true
+
+	return args.Name + "-id", args.Inputs, nil
+}
+
+// Call returns nothing for every function, so data sources read as empty during validation.
+func (mocks *validationMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return resource.PropertyMap{}, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestDeclareWithMocksDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		declare  func(ctx *pulumi.Context, lair *github.Repository, tx5 *github.Repository) error
+		expected ValidationErrors
+	}{
+		{
+			name: "different names",
+			declare: func(ctx *pulumi.Context, lair *github.Repository, tx5 *github.Repository) error {
+				if _, err := github.NewBranch(ctx, "lair-branch", &github.BranchArgs{Repository: lair.Name, Branch: pulumi.String("develop")}, InRepository(ctx, "lair")); err != nil {
+					return err
+				}
+				_, err := github.NewBranch(ctx, "tx5-branch", &github.BranchArgs{Repository: tx5.Name, Branch: pulumi.String("develop")}, InRepository(ctx, "tx5"))
+				return err
+			},
+		},
+		{
+			name: "same name in different components",
+			declare: func(ctx *pulumi.Context, lair *github.Repository, tx5 *github.Repository) error {
+				if _, err := github.NewBranch(ctx, "develop-branch", &github.BranchArgs{Repository: lair.Name, Branch: pulumi.String("develop")}, InRepository(ctx, "lair")); err != nil {
+					return err
+				}
+				_, err := github.NewBranch(ctx, "develop-branch", &github.BranchArgs{Repository: tx5.Name, Branch: pulumi.String("develop")}, InRepository(ctx, "tx5"))
+				return err
+			},
+			expected: ValidationErrors{
+				{Repository: "tx5", Problem: `the github:index/branch:Branch resource "develop-branch" is declared more than once`},
+			},
+		},
+		{
+			name: "same name with different types",
+			declare: func(ctx *pulumi.Context, lair *github.Repository, tx5 *github.Repository) error {
+				if _, err := github.NewBranch(ctx, "lair-develop", &github.BranchArgs{Repository: lair.Name, Branch: pulumi.String("develop")}, InRepository(ctx, "lair")); err != nil {
+					return err
+				}
+				_, err := github.NewBranchDefault(ctx, "lair-develop", &github.BranchDefaultArgs{Repository: lair.Name, Branch: pulumi.String("develop")}, InRepository(ctx, "lair"))
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, duplicates, err := declareWithMocks("holochain", "github", map[string]string{}, func(ctx *pulumi.Context) error {
+				lairArgs := standardRepositoryArgs("lair", nil)
+				lair, err := NewHolochainRepository(ctx, "lair", &lairArgs)
+				if err != nil {
+					return err
+				}
+				tx5Args := standardRepositoryArgs("tx5", nil)
+				tx5, err := NewHolochainRepository(ctx, "tx5", &tx5Args)
+				if err != nil {
+					return err
+				}
+
+				return test.declare(ctx, lair, tx5)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(duplicates, test.expected) {
+				t.Errorf("expected the duplicates %v, got %v", test.expected, duplicates)
+			}
+		})
+	}
+}
+
+func TestValidateOrganization(t *testing.T) {
+	declare := func(ctx *pulumi.Context) error {
+		args := StandardRepositoryArgs(ctx, "lair", nil,
+			Intentional("HasWiki", pulumi.Bool(true), ""),
+		)
+		lair, err := NewHolochainRepository(ctx, "lair", &args)
+		if err != nil {
+			return err
+		}
+		requireSecret(ctx, "", "lair", "cachixAuthToken")
+		for range 2 {
+			if _, err := github.NewBranch(ctx, "lair-develop", &github.BranchArgs{Repository: lair.Name, Branch: pulumi.String("develop")}, InRepository(ctx, "lair")); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var validationErr error
+	_, _, err := declareWithMocks("holochain", "github", map[string]string{}, func(ctx *pulumi.Context) error {
+		validationErr = validateOrganization(ctx, declare)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ValidationErrors{
+		{Repository: "lair", Problem: "the intentional override of HasWiki must have a reason"},
+		{Repository: "lair", Problem: "missing required configuration variable 'holochain:cachixAuthToken'; run `pulumi config` to set"},
+		{Repository: "lair", Problem: `the github:index/branch:Branch resource "lair-develop" is declared more than once`},
+	}
+	var validationErrors ValidationErrors
+	if !errors.As(validationErr, &validationErrors) || !reflect.DeepEqual(validationErrors, expected) {
+		t.Errorf("expected the problems %v, got %v", expected, validationErr)
+	}
+}
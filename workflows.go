@@ -0,0 +1,83 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CiPassCheck is the status check that rulesets require. Each repository must report it from a
+// single job that only passes when all the other checks have passed, which the managed
+// WorkflowCiPass does.
+const CiPassCheck = "ci_pass"
+
+// conventionalCommitTypes are the commit types accepted by the managed WorkflowPullRequestTitle.
+var conventionalCommitTypes = []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+// Workflow is a GitHub Actions workflow that can be distributed to .github/workflows/.
+type Workflow string
+
+const (
+	// WorkflowCiPass reports the CiPassCheck once every other check on a PR has passed.
+	WorkflowCiPass Workflow = "ci_pass.yml"
+	// WorkflowPullRequestTitle checks that PR titles follow conventional commits.
+	WorkflowPullRequestTitle Workflow = "pr_title.yml"
+)
+
+//go:embed files/workflows/ci_pass.yml
+var ciPassWorkflowContent string
+
+//go:embed files/workflows/pr_title.yml
+var pullRequestTitleWorkflowContent string
+
+var workflowTemplates = map[Workflow]sharedFileTemplate{
+	WorkflowCiPass:           {path: ".github/workflows/ci_pass.yml", template: parseSharedFileTemplate("ci_pass.yml", ciPassWorkflowContent)},
+	WorkflowPullRequestTitle: {path: ".github/workflows/pr_title.yml", template: parseSharedFileTemplate("pr_title.yml", pullRequestTitleWorkflowContent)},
+}
+
+// ActionPin is the commit that a tag of an action pointed to when it was reviewed.
+type ActionPin struct {
+	Version string
+	SHA     string
+}
+
+// actionPins are the only actions that managed workflows can use. Actions are referenced by commit
+// SHA so that a tag being moved, maliciously or not, cannot change what runs in every repository.
+// To update an action, review the new release and replace both the version and the SHA.
+var actionPins = map[string]ActionPin{
+	"actions/github-script": {Version: "v7.0.1", SHA: "60a0d83039c74a4aee543508d2ffcb1c3799cdea"},
+}
+
+// pinnedAction renders the `uses` reference for an action, for example
+// `actions/github-script@60a0d83039c74a4aee543508d2ffcb1c3799cdea # v7.0.1`.
+func pinnedAction(action string) (string, error) {
+	pin, ok := actionPins[action]
+	if !ok {
+		return "", fmt.Errorf("action %q is not pinned, add it to actionPins", action)
+	}
+
+	return fmt.Sprintf("%s@%s # %s", action, pin.SHA, pin.Version), nil
+}
+
+var (
+	workflowUses = regexp.MustCompile(`(?m)^\s*(?:-\s*)?uses:\s*(\S+)`)
+	commitSHA    = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// verifyPinnedActions checks that every action used by a rendered workflow is referenced by
+// commit SHA, so that a `uses` line written without the `action` template function is caught.
+func verifyPinnedActions(path string, content string) error {
+	for _, match := range workflowUses.FindAllStringSubmatch(content, -1) {
+		uses := match[1]
+		if strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+			continue
+		}
+		_, ref, _ := strings.Cut(uses, "@")
+		if !commitSHA.MatchString(ref) {
+			return fmt.Errorf("%s uses %s, which is not pinned to a commit SHA", path, uses)
+		}
+	}
+
+	return nil
+}